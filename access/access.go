@@ -0,0 +1,290 @@
+package access
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// @Author :George
+// @File: access
+// @Version: 1.0.0
+// @Date 2026/10/18 23:00
+
+// Effect is what a rule decides for the requests it matches.
+type Effect int
+
+const (
+	// DENY denies the request. It is the zero Effect, so that a rule or a
+	// Rules without one denies.
+	DENY Effect = iota
+	// ALLOW allows the request.
+	ALLOW
+	// AUTHENTICATED allows the request of an authenticated subject.
+	AUTHENTICATED
+	// ROLE allows the request of an authenticated subject with any of the
+	// roles of the rule.
+	ROLE
+)
+
+func (e Effect) String() string {
+	switch e {
+	case DENY:
+		return "DENY"
+	case ALLOW:
+		return "ALLOW"
+	case AUTHENTICATED:
+		return "AUTHENTICATED"
+	case ROLE:
+		return "ROLE"
+	default:
+		return "Effect(" + strconv.Itoa(int(e)) + ")"
+	}
+}
+
+// Strategy chooses the winning rule among those matching a request.
+type Strategy int
+
+const (
+	// FIRST_MATCH picks the first matching rule in the order they were given.
+	FIRST_MATCH Strategy = iota
+	// MOST_SPECIFIC picks the matching rule with the most specific pattern, as
+	// ordered by antpathmatcher.AntPatternComparator, the first given if
+	// several are equally specific.
+	MOST_SPECIFIC
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case FIRST_MATCH:
+		return "FIRST_MATCH"
+	case MOST_SPECIFIC:
+		return "MOST_SPECIFIC"
+	default:
+		return "Strategy(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+//region Rule
+
+// Rule applies Effect to the requests whose path matches Pattern and whose
+// method is one of Methods, or any method if there are none.
+type Rule struct {
+	Pattern string
+	Methods []string
+	Effect  Effect
+	// Roles are the roles a ROLE rule accepts.
+	Roles []string
+}
+
+func Allow(pattern string, methods ...string) Rule {
+	return Rule{Pattern: pattern, Methods: methods, Effect: ALLOW}
+}
+
+func Deny(pattern string, methods ...string) Rule {
+	return Rule{Pattern: pattern, Methods: methods, Effect: DENY}
+}
+
+func Authenticated(pattern string, methods ...string) Rule {
+	return Rule{Pattern: pattern, Methods: methods, Effect: AUTHENTICATED}
+}
+
+func HasAnyRole(pattern string, roles []string, methods ...string) Rule {
+	return Rule{Pattern: pattern, Methods: methods, Effect: ROLE, Roles: roles}
+}
+
+func (r *Rule) matchesMethod(method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) allows(subject Subject) bool {
+	switch r.Effect {
+	case ALLOW:
+		return true
+	case AUTHENTICATED:
+		return subject.Authenticated
+	case ROLE:
+		if !subject.Authenticated {
+			return false
+		}
+		for _, role := range r.Roles {
+			if subject.HasRole(role) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r Rule) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(r.Effect.String())
+	if r.Effect == ROLE {
+		builder.WriteString("(" + strings.Join(r.Roles, ",") + ")")
+	}
+	if len(r.Methods) > 0 {
+		builder.WriteString(" " + strings.Join(r.Methods, ","))
+	}
+	builder.WriteString(" " + r.Pattern)
+	return builder.String()
+}
+
+//endregion
+
+// Subject is who makes a request.
+type Subject struct {
+	Authenticated bool
+	Roles         []string
+}
+
+func (s Subject) HasRole(role string) bool {
+	for _, r := range s.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Decision explains the evaluation of a request.
+type Decision struct {
+	Allowed bool
+	// Rule is the winning rule, nil if no rule matched and the default applied.
+	Rule *Rule
+	// Index is the index of Rule among the rules, -1 if there is none.
+	Index     int
+	Pattern   string
+	Variables map[string]string
+	// Considered are the rules matching the request, in the order the strategy
+	// ranked them; the first one won.
+	Considered []Rule
+}
+
+// Unauthenticated reports whether the request was denied by a rule that
+// would allow an authenticated subject, which deserves a 401 rather than a 403.
+func (d Decision) Unauthenticated(subject Subject) bool {
+	return !d.Allowed && !subject.Authenticated && d.Rule != nil && (d.Rule.Effect == AUTHENTICATED || d.Rule.Effect == ROLE)
+}
+
+func (d Decision) String() string {
+	verdict := "denied"
+	if d.Allowed {
+		verdict = "allowed"
+	}
+	if d.Rule == nil {
+		return verdict + " by default"
+	}
+	return verdict + " by rule " + strconv.Itoa(d.Index) + " (" + d.Rule.String() + ")"
+}
+
+//region Rules
+
+// Rules is an ordered list of rules evaluated with a Strategy. It is safe for
+// concurrent use.
+type Rules struct {
+	strategy Strategy
+	rules    []Rule
+	index    *antpathmatcher.PatternMap[[]int]
+	// Default is the effect applied to the requests no rule matches, DENY
+	// unless set before the Rules is used.
+	Default Effect
+}
+
+// NewRules compiles the patterns of rules with opts. The error is the one
+// antpathmatcher.Compile returns for the first invalid pattern.
+func NewRules(strategy Strategy, rules []Rule, opts ...antpathmatcher.Option) (*Rules, error) {
+	index := antpathmatcher.NewPatternMap[[]int](opts...)
+	byPattern := make(map[string][]int)
+	for k := range rules {
+		byPattern[rules[k].Pattern] = append(byPattern[rules[k].Pattern], k)
+		if err := index.Put(rules[k].Pattern, byPattern[rules[k].Pattern]); err != nil {
+			return nil, err
+		}
+	}
+	return &Rules{
+		strategy: strategy,
+		rules:    append([]Rule(nil), rules...),
+		index:    index,
+	}, nil
+}
+
+// Evaluate decides whether subject may make a request with method and path.
+// An empty method is GET, as in net/http.
+func (r *Rules) Evaluate(method, path string, subject Subject) Decision {
+	if method == "" {
+		method = http.MethodGet
+	}
+	type candidate struct {
+		id        int
+		variables map[string]string
+	}
+	var candidates []candidate
+	for _, match := range r.index.GetAll(path) {
+		for _, id := range match.Value {
+			if r.rules[id].matchesMethod(method) {
+				candidates = append(candidates, candidate{id: id, variables: match.Variables})
+			}
+		}
+	}
+	if r.strategy == FIRST_MATCH {
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].id < candidates[j].id
+		})
+	}
+	decision := Decision{Index: -1}
+	if len(candidates) == 0 {
+		decision.Allowed = (&Rule{Effect: r.Default}).allows(subject)
+		return decision
+	}
+	decision.Considered = make([]Rule, len(candidates))
+	for k := range candidates {
+		decision.Considered[k] = r.rules[candidates[k].id]
+	}
+	winner := candidates[0]
+	decision.Rule = &decision.Considered[0]
+	decision.Index = winner.id
+	decision.Pattern = decision.Rule.Pattern
+	decision.Variables = winner.variables
+	decision.Allowed = decision.Rule.allows(subject)
+	return decision
+}
+
+func (r *Rules) EvaluateRequest(req *http.Request, subject Subject) Decision {
+	return r.Evaluate(req.Method, req.URL.Path, subject)
+}
+
+// Middleware returns a middleware evaluating each request with the subject
+// returned by subject. A denied request gets a 401 if an authenticated
+// subject could have been allowed, a 403 otherwise; onDecision, if not nil,
+// is called with every decision first, to log it for instance.
+func (r *Rules) Middleware(subject func(*http.Request) Subject, onDecision func(*http.Request, Decision)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			s := subject(req)
+			decision := r.EvaluateRequest(req, s)
+			if onDecision != nil {
+				onDecision(req, decision)
+			}
+			switch {
+			case decision.Allowed:
+				next.ServeHTTP(w, req)
+			case decision.Unauthenticated(s):
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			default:
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			}
+		})
+	}
+}
+
+//endregion
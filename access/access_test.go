@@ -0,0 +1,184 @@
+package access
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// @Author :George
+// @File: access_test
+// @Version: 1.0.0
+// @Date 2026/10/18 23:20
+
+var (
+	anonymous = Subject{}
+	user      = Subject{Authenticated: true, Roles: []string{"USER"}}
+	admin     = Subject{Authenticated: true, Roles: []string{"USER", "ADMIN"}}
+)
+
+func testRules() []Rule {
+	return []Rule{
+		Allow("/public/**"),
+		Authenticated("/api/**"),
+		HasAnyRole("/api/admin/**", []string{"ADMIN"}),
+		Deny("/api/admin/{section}/delete", http.MethodPost),
+		Allow("/api/items/{id}", http.MethodGet, http.MethodHead),
+	}
+}
+
+func Test_firstMatch(t *testing.T) {
+	e := assert.New(t)
+	rules, err := NewRules(FIRST_MATCH, testRules())
+	e.NoError(err)
+
+	d := rules.Evaluate(http.MethodGet, "/public/css/site.css", anonymous)
+	e.True(d.Allowed)
+	e.Equal(d.Index, 0)
+	e.Equal(d.Pattern, "/public/**")
+
+	// the first match wins, even though later rules are more specific
+	d = rules.Evaluate(http.MethodGet, "/api/admin/users", user)
+	e.True(d.Allowed)
+	e.Equal(d.Index, 1)
+	e.Equal(d.Considered, []Rule{testRules()[1], testRules()[2]})
+
+	d = rules.Evaluate(http.MethodGet, "/api/items/7", anonymous)
+	e.False(d.Allowed)
+	e.True(d.Unauthenticated(anonymous))
+	e.Equal(d.Index, 1)
+	e.Equal(d.Considered, []Rule{testRules()[1], testRules()[4]})
+}
+
+func Test_mostSpecific(t *testing.T) {
+	e := assert.New(t)
+	rules, err := NewRules(MOST_SPECIFIC, testRules())
+	e.NoError(err)
+
+	d := rules.Evaluate(http.MethodGet, "/api/admin/users", user)
+	e.False(d.Allowed)
+	e.False(d.Unauthenticated(user))
+	e.Equal(d.Index, 2)
+	e.Equal(d.Rule.Effect, ROLE)
+	e.True(rules.Evaluate(http.MethodGet, "/api/admin/users", admin).Allowed)
+
+	d = rules.Evaluate(http.MethodPost, "/api/admin/users/delete", admin)
+	e.False(d.Allowed)
+	e.Equal(d.Index, 3)
+	e.Equal(d.Pattern, "/api/admin/{section}/delete")
+	e.Equal(d.Variables, map[string]string{"section": "users"})
+	e.Equal(d.Considered, []Rule{testRules()[3], testRules()[2], testRules()[1]})
+
+	// the method of the most specific rule does not match
+	d = rules.Evaluate(http.MethodGet, "/api/admin/users/delete", admin)
+	e.True(d.Allowed)
+	e.Equal(d.Index, 2)
+
+	d = rules.Evaluate("", "/api/items/7", anonymous)
+	e.True(d.Allowed)
+	e.Equal(d.Index, 4)
+	e.Equal(d.Variables, map[string]string{"id": "7"})
+	e.False(rules.Evaluate(http.MethodPut, "/api/items/7", anonymous).Allowed)
+}
+
+func Test_samePatternRules(t *testing.T) {
+	e := assert.New(t)
+	for _, strategy := range []Strategy{FIRST_MATCH, MOST_SPECIFIC} {
+		rules, err := NewRules(strategy, []Rule{
+			Deny("/docs/**", http.MethodDelete),
+			Allow("/docs/**"),
+			Deny("/docs/**"),
+		})
+		e.NoError(err)
+		e.False(rules.Evaluate(http.MethodDelete, "/docs/a", admin).Allowed, strategy)
+		d := rules.Evaluate(http.MethodGet, "/docs/a", admin)
+		e.True(d.Allowed, strategy)
+		e.Equal(d.Index, 1, strategy)
+		e.Len(d.Considered, 2, strategy)
+	}
+}
+
+func Test_default(t *testing.T) {
+	e := assert.New(t)
+	rules, err := NewRules(MOST_SPECIFIC, testRules())
+	e.NoError(err)
+	d := rules.Evaluate(http.MethodGet, "/elsewhere", admin)
+	e.False(d.Allowed)
+	e.Nil(d.Rule)
+	e.Equal(d.Index, -1)
+	e.Empty(d.Considered)
+	e.Equal(d.String(), "denied by default")
+
+	rules.Default = AUTHENTICATED
+	e.True(rules.Evaluate(http.MethodGet, "/elsewhere", user).Allowed)
+	e.False(rules.Evaluate(http.MethodGet, "/elsewhere", anonymous).Allowed)
+}
+
+func Test_newRulesError(t *testing.T) {
+	e := assert.New(t)
+	_, err := NewRules(FIRST_MATCH, []Rule{Allow("/a"), Allow("/{id:(a)}")})
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+}
+
+func Test_caseInsensitiveRules(t *testing.T) {
+	e := assert.New(t)
+	rules, err := NewRules(FIRST_MATCH, []Rule{Allow("/public/**")}, antpathmatcher.WithCaseSensitive(false))
+	e.NoError(err)
+	e.True(rules.Evaluate(http.MethodGet, "/PUBLIC/a", anonymous).Allowed)
+}
+
+func Test_strings(t *testing.T) {
+	e := assert.New(t)
+	e.Equal(testRules()[2].String(), "ROLE(ADMIN) /api/admin/**")
+	e.Equal(testRules()[4].String(), "ALLOW GET,HEAD /api/items/{id}")
+	e.Equal(Effect(9).String(), "Effect(9)")
+	e.Equal(MOST_SPECIFIC.String(), "MOST_SPECIFIC")
+	e.Equal(Strategy(9).String(), "Strategy(9)")
+
+	rules, _ := NewRules(MOST_SPECIFIC, testRules())
+	e.Equal(rules.Evaluate(http.MethodGet, "/api/admin/x", user).String(), "denied by rule 2 (ROLE(ADMIN) /api/admin/**)")
+}
+
+func Test_middleware(t *testing.T) {
+	e := assert.New(t)
+	rules, err := NewRules(MOST_SPECIFIC, testRules())
+	e.NoError(err)
+	var decisions []Decision
+	handler := rules.Middleware(func(r *http.Request) Subject {
+		switch r.Header.Get("X-User") {
+		case "admin":
+			return admin
+		case "user":
+			return user
+		}
+		return anonymous
+	}, func(r *http.Request, d Decision) {
+		decisions = append(decisions, d)
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	tests := []struct {
+		user   string
+		path   string
+		status int
+	}{
+		{"", "/public/a", http.StatusNoContent},
+		{"", "/api/orders", http.StatusUnauthorized},
+		{"user", "/api/orders", http.StatusNoContent},
+		{"", "/api/admin/a", http.StatusUnauthorized},
+		{"user", "/api/admin/a", http.StatusForbidden},
+		{"admin", "/api/admin/a", http.StatusNoContent},
+		{"admin", "/other", http.StatusForbidden},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, test.path, nil)
+		r.Header.Set("X-User", test.user)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		e.Equal(w.Code, test.status, "%s %s", test.user, test.path)
+	}
+	e.Len(decisions, len(tests))
+}
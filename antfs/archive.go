@@ -0,0 +1,164 @@
+package antfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// @Author :George
+// @File: archive
+// @Version: 1.0.0
+// @Date 2026/10/19 01:40
+
+//region TarReader
+
+// TarReader reads the entries of a tar archive whose names are selected by
+// include and exclude patterns, like a FileSet selects names. The names are
+// matched, and returned, without a leading "./" or "/"; the entries whose
+// names are then not valid in an fs.FS, such as "../etc/passwd", are skipped.
+// Once an entry or a path shows that a directory cannot hold a selected name,
+// the entries below it are skipped without being matched.
+type TarReader struct {
+	r        *tar.Reader
+	selector *entrySelector
+}
+
+func NewTarReader(r *tar.Reader, includes, excludes []string, opts ...antpathmatcher.Option) (*TarReader, error) {
+	s, err := newSelector(includes, excludes, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TarReader{r: r, selector: newEntrySelector(s)}, nil
+}
+
+// Next advances to the next selected entry, returning io.EOF at the end of
+// the archive, like tar.Reader.Next. The header has the name that was matched,
+// followed by "/" if it was in the archive.
+func (t *TarReader) Next() (*tar.Header, error) {
+	for {
+		header, err := t.r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if name, ok := t.selector.selectEntry(header.Name); ok {
+			header.Name = entryName(name, header.Name)
+			return header, nil
+		}
+	}
+}
+
+// Read reads from the current entry.
+func (t *TarReader) Read(b []byte) (int, error) {
+	return t.r.Read(b)
+}
+
+//endregion
+
+// ZipFiles returns the files of a zip archive whose names are selected by
+// include and exclude patterns, matched and named like the entries of a
+// TarReader, in the order of the archive. The files are copies of those of r,
+// whose names are left as they are.
+func ZipFiles(r *zip.Reader, includes, excludes []string, opts ...antpathmatcher.Option) ([]*zip.File, error) {
+	s, err := newSelector(includes, excludes, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	entries := newEntrySelector(s)
+	var files []*zip.File
+	for _, file := range r.File {
+		if name, ok := entries.selectEntry(file.Name); ok {
+			selected := *file
+			selected.Name = entryName(name, file.Name)
+			files = append(files, &selected)
+		}
+	}
+	return files, nil
+}
+
+// entryName is the matched name of an entry, followed by "/" if its name in
+// the archive was.
+func entryName(name, archived string) string {
+	if strings.HasSuffix(archived, SEPARATOR) {
+		return name + SEPARATOR
+	}
+	return name
+}
+
+// WriteTar writes the names of fsys selected by include and exclude patterns
+// to w, directories included, without entering the directories that cannot
+// hold a selected name. Symbolic links are followed like by a FileSet: a link
+// to a file is written as that file and a link to a directory as that
+// directory, through at most DEFAULT_MAX_LEVELS_OF_SYMLINKS links on the way
+// to a name. The links past that limit and broken links are left out. The
+// caller closes w.
+func WriteTar(w *tar.Writer, fsys fs.FS, includes, excludes []string, opts ...antpathmatcher.Option) error {
+	return writeArchive(fsys, includes, excludes, opts, func(name string, info fs.FileInfo) (io.Writer, error) {
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return nil, err
+		}
+		header.Name = name
+		if err := w.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		return w, nil
+	})
+}
+
+// WriteZip is WriteTar for zip archives, whose files it deflates.
+func WriteZip(w *zip.Writer, fsys fs.FS, includes, excludes []string, opts ...antpathmatcher.Option) error {
+	return writeArchive(fsys, includes, excludes, opts, func(name string, info fs.FileInfo) (io.Writer, error) {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return nil, err
+		}
+		header.Name = name
+		if !info.IsDir() {
+			header.Method = zip.Deflate
+		}
+		return w.CreateHeader(header)
+	})
+}
+
+// writeArchive calls create for each selected name, with a trailing "/" for
+// directories, and copies the contents of the files to the writer it returns.
+func writeArchive(fsys fs.FS, includes, excludes []string, opts []antpathmatcher.Option, create func(name string, info fs.FileInfo) (io.Writer, error)) error {
+	s, err := newSelector(includes, excludes, nil, opts...)
+	if err != nil {
+		return err
+	}
+	scanner := &fileSetScanner{fileSet: &FileSet{FS: fsys}, selector: s, result: &ScanResult{}}
+	scanner.visit = func(name string, isDir bool) error {
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			return err
+		}
+		if isDir {
+			_, err := create(name+SEPARATOR, info)
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("antfs: cannot archive %s: not a regular file", name)
+		}
+		w, err := create(name, info)
+		if err != nil {
+			return err
+		}
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(w, file)
+		return err
+	}
+	if !s.descend(".") {
+		return nil
+	}
+	return scanner.scan(".", 0)
+}
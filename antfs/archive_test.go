@@ -0,0 +1,226 @@
+package antfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// @Author :George
+// @File: archive_test
+// @Version: 1.0.0
+// @Date 2026/10/19 02:00
+
+func tarEntries(t *testing.T, data []byte, includes, excludes []string) map[string]string {
+	e := assert.New(t)
+	r, err := NewTarReader(tar.NewReader(bytes.NewReader(data)), includes, excludes)
+	e.NoError(err)
+	entries := make(map[string]string)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return entries
+		}
+		e.NoError(err)
+		contents, err := io.ReadAll(r)
+		e.NoError(err)
+		entries[header.Name] = string(contents)
+	}
+}
+
+func Test_tar(t *testing.T) {
+	e := assert.New(t)
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	e.NoError(WriteTar(w, testFS(), []string{"bin/", "configs/dev/**", "docs/**/*.md"}, []string{"**/*.debug", "**/*~"}))
+	e.NoError(w.Close())
+
+	e.Equal(tarEntries(t, buf.Bytes(), nil, nil), map[string]string{
+		"bin/":                 "",
+		"bin/app":              "binary",
+		"configs/dev/":         "",
+		"configs/dev/app.yaml": "port: 80",
+		"docs/guide/intro.md":  "# Intro",
+	})
+	e.Equal(tarEntries(t, buf.Bytes(), []string{"**/*.yaml", "bin"}, nil), map[string]string{
+		"bin/":                 "",
+		"configs/dev/app.yaml": "port: 80",
+	})
+	e.Equal(tarEntries(t, buf.Bytes(), nil, []string{"bin/", "docs/"}), map[string]string{
+		"configs/dev/":         "",
+		"configs/dev/app.yaml": "port: 80",
+	})
+}
+
+func Test_tarReaderNames(t *testing.T) {
+	e := assert.New(t)
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	e.NoError(w.WriteHeader(&tar.Header{Name: "./app/", Typeflag: tar.TypeDir}))
+	for _, name := range []string{"./app/main.go", "/app/util.go", "app/../app/x_test.go"} {
+		e.NoError(w.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg}))
+	}
+	e.NoError(w.Close())
+	e.Equal(tarEntries(t, buf.Bytes(), []string{"app/*.go"}, []string{"**/*_test.go"}), map[string]string{
+		"app/main.go": "",
+		"app/util.go": "",
+	})
+	e.Equal(tarEntries(t, buf.Bytes(), []string{"app/"}, nil), map[string]string{
+		"app/":        "",
+		"app/main.go": "",
+		"app/util.go": "",
+	})
+}
+
+var traversalNames = []string{"../conf/evil.yaml", "conf/../../evil.yaml", "/conf/abs.yaml", "./conf/ok.yaml", "conf/ok2.yaml", "//conf/double.yaml", "conf//empty.yaml"}
+
+func Test_tarReaderSkipsTraversal(t *testing.T) {
+	e := assert.New(t)
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	for _, name := range traversalNames {
+		e.NoError(w.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg}))
+	}
+	e.NoError(w.Close())
+	e.Equal(tarEntries(t, buf.Bytes(), []string{"conf/**"}, nil), map[string]string{
+		"conf/abs.yaml": "",
+		"conf/ok.yaml":  "",
+		"conf/ok2.yaml": "",
+	})
+	e.Equal(tarEntries(t, buf.Bytes(), []string{"**"}, nil), map[string]string{
+		"conf/abs.yaml": "",
+		"conf/ok.yaml":  "",
+		"conf/ok2.yaml": "",
+	})
+}
+
+func Test_zipFilesSkipsTraversal(t *testing.T) {
+	e := assert.New(t)
+	buf := bytes.Buffer{}
+	w := zip.NewWriter(&buf)
+	for _, name := range traversalNames {
+		_, err := w.Create(name)
+		e.NoError(err)
+	}
+	e.NoError(w.Close())
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	e.NoError(err)
+	files, err := ZipFiles(r, []string{"**"}, nil)
+	e.NoError(err)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name)
+		rc, err := file.Open()
+		e.NoError(err)
+		e.NoError(rc.Close())
+	}
+	e.Equal(names, []string{"conf/abs.yaml", "conf/ok.yaml", "conf/ok2.yaml"})
+	// the reader keeps the names of the archive
+	e.Equal(r.File[2].Name, "/conf/abs.yaml")
+}
+
+func Test_zip(t *testing.T) {
+	e := assert.New(t)
+	buf := bytes.Buffer{}
+	w := zip.NewWriter(&buf)
+	e.NoError(WriteZip(w, testFS(), []string{"bin/", "configs/**", "docs/"}, []string{"**/*~", "configs/prod/"}))
+	e.NoError(w.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	e.NoError(err)
+	var names []string
+	for _, file := range r.File {
+		names = append(names, file.Name)
+	}
+	e.Equal(names, []string{"bin/", "bin/app", "bin/app.debug", "configs/", "configs/.DS_Store", "configs/dev/",
+		"configs/dev/app.yaml", "docs/", "docs/Guide.MD", "docs/guide/", "docs/guide/intro.md"})
+
+	files, err := ZipFiles(r, []string{"**/*.md", "configs/*/*"}, nil)
+	e.NoError(err)
+	names = nil
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	e.Equal(names, []string{"configs/dev/app.yaml", "docs/guide/intro.md"})
+
+	rc, err := files[0].Open()
+	e.NoError(err)
+	contents, err := io.ReadAll(rc)
+	e.NoError(err)
+	e.NoError(rc.Close())
+	e.Equal(string(contents), "port: 80")
+}
+
+func Test_tarSymlinks(t *testing.T) {
+	e := assert.New(t)
+	dir := t.TempDir()
+	e.NoError(os.MkdirAll(filepath.Join(dir, "real"), 0o755))
+	e.NoError(os.WriteFile(filepath.Join(dir, "real", "a.txt"), []byte("a"), 0o644))
+	if err := os.Symlink("real", filepath.Join(dir, "link")); err != nil {
+		t.Skip("symbolic links are not supported:", err)
+	}
+	// a loop: real/self leads back to real
+	e.NoError(os.Symlink(".", filepath.Join(dir, "real", "self")))
+
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	e.NoError(WriteTar(w, os.DirFS(dir), []string{"link/", "link/*"}, nil))
+	e.NoError(w.Close())
+	// the contents of link are written below it, not as an empty directory
+	e.Equal(tarEntries(t, buf.Bytes(), nil, []string{"**/self/**/*"}), map[string]string{
+		"link/":      "",
+		"link/a.txt": "a",
+		"link/self/": "",
+	})
+
+	// at most DEFAULT_MAX_LEVELS_OF_SYMLINKS links are followed on the way to
+	// a name
+	buf.Reset()
+	w = tar.NewWriter(&buf)
+	e.NoError(WriteTar(w, os.DirFS(dir), []string{"**/a.txt"}, nil))
+	e.NoError(w.Close())
+	expected := make(map[string]string)
+	for k := 0; k <= DEFAULT_MAX_LEVELS_OF_SYMLINKS; k++ {
+		expected["real/"+strings.Repeat("self/", k)+"a.txt"] = "a"
+		if k < DEFAULT_MAX_LEVELS_OF_SYMLINKS {
+			expected["link/"+strings.Repeat("self/", k)+"a.txt"] = "a"
+		}
+	}
+	e.Equal(tarEntries(t, buf.Bytes(), nil, nil), expected)
+}
+
+func Test_archiveInvalidPattern(t *testing.T) {
+	e := assert.New(t)
+	_, err := NewTarReader(tar.NewReader(&bytes.Buffer{}), []string{"{id:(a)}"}, nil)
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+	_, err = ZipFiles(&zip.Reader{}, nil, []string{"{id:(a)}"})
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+	e.True(errors.Is(WriteZip(zip.NewWriter(io.Discard), testFS(), []string{"{id:(a)}"}, nil), antpathmatcher.ErrCapturingGroup))
+}
+
+func Test_entrySelectorSkipsDirectories(t *testing.T) {
+	e := assert.New(t)
+	s, err := newSelector([]string{"src/**/*.go"}, []string{"src/gen/"}, nil)
+	e.NoError(err)
+	entries := newEntrySelector(s)
+	for _, name := range []string{"vendor/a/b/c.go", "vendor/a/d.go", "src/gen/x/y.go", "src/main.go", "src/pkg/"} {
+		entries.selectEntry(name)
+	}
+	// the directories below vendor and src/gen are never matched
+	e.Equal(entries.descends, map[string]bool{"vendor": false, "src": true, "src/gen": false})
+
+	name, ok := entries.selectEntry("./src/pkg/a.go")
+	e.Equal(name, "src/pkg/a.go")
+	e.True(ok)
+	name, ok = entries.selectEntry("/")
+	e.Equal(name, ".")
+	e.False(ok)
+}
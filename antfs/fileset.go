@@ -0,0 +1,213 @@
+package antfs
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"io/fs"
+	"path"
+)
+
+// @Author :George
+// @File: fileset
+// @Version: 1.0.0
+// @Date 2026/10/19 00:20
+
+// DEFAULT_MAX_LEVELS_OF_SYMLINKS is how many symbolic links to directories a
+// FileSet follows in a row by default, as in Ant.
+const DEFAULT_MAX_LEVELS_OF_SYMLINKS = 5
+
+// DEFAULT_EXCLUDES are the default excludes of Ant: editor backups and the
+// files of version control systems.
+var DEFAULT_EXCLUDES = []string{
+	"**/*~",
+	"**/#*#",
+	"**/.#*",
+	"**/%*%",
+	"**/._*",
+	"**/CVS",
+	"**/CVS/**",
+	"**/.cvsignore",
+	"**/SCCS",
+	"**/SCCS/**",
+	"**/vssver.scc",
+	"**/.svn",
+	"**/.svn/**",
+	"**/.DS_Store",
+	"**/.git",
+	"**/.git/**",
+	"**/.gitattributes",
+	"**/.gitignore",
+	"**/.gitmodules",
+	"**/.hg",
+	"**/.hg/**",
+	"**/.hgignore",
+	"**/.hgsub",
+	"**/.hgsubstate",
+	"**/.hgtags",
+	"**/.bzr",
+	"**/.bzr/**",
+	"**/.bzrignore",
+}
+
+//region FileSet
+
+// FileSet selects files and directories of an fs.FS like the <fileset> of
+// Apache Ant. Without Includes everything is included; a pattern ending in "/"
+// stands for the pattern followed by "**". Its zero value has Ant's defaults:
+// default excludes, case-sensitive matching and symbolic links followed.
+type FileSet struct {
+	FS       fs.FS
+	Includes []string
+	Excludes []string
+	// NoDefaultExcludes leaves DEFAULT_EXCLUDES out of Excludes.
+	NoDefaultExcludes bool
+	CaseInsensitive   bool
+	// NoFollowSymlinks leaves symbolic links out of the scan. Otherwise it
+	// enters the links to directories and selects the links to files. An fs.FS
+	// cannot tell where a link leads, so instead of Ant's loop detection at most
+	// MaxLevelsOfSymlinks links are followed on the way to a name,
+	// DEFAULT_MAX_LEVELS_OF_SYMLINKS if it is 0.
+	NoFollowSymlinks    bool
+	MaxLevelsOfSymlinks int
+}
+
+// Exclusion is a name that an include pattern selected and an exclude
+// pattern dropped.
+type Exclusion struct {
+	Name string
+	Dir  bool
+	// Pattern is the first exclude pattern that matched the name, as given in
+	// Excludes or DEFAULT_EXCLUDES.
+	Pattern string
+	// Default reports whether Pattern is one of DEFAULT_EXCLUDES.
+	Default bool
+}
+
+// ScanResult lists the names found by FileSet.Scan, in the order of a
+// depth-first walk in lexical order. "." stands for the root of the fs.FS.
+type ScanResult struct {
+	Files []string
+	Dirs  []string
+	// Excluded are the names included and then excluded.
+	Excluded []Exclusion
+	// NotIncluded are the names, among those scanned, that no include matched.
+	NotIncluded []string
+	// NotFollowedSymlinks are the symbolic links left out, because of
+	// NoFollowSymlinks or because MaxLevelsOfSymlinks was reached.
+	NotFollowedSymlinks []string
+}
+
+func NewFileSet(fsys fs.FS) *FileSet {
+	return &FileSet{FS: fsys}
+}
+
+func (f *FileSet) selector() (*selector, error) {
+	var defaults []string
+	if !f.NoDefaultExcludes {
+		defaults = DEFAULT_EXCLUDES
+	}
+	return newSelector(f.Includes, f.Excludes, defaults, antpathmatcher.WithCaseSensitive(!f.CaseInsensitive))
+}
+
+func (f *FileSet) maxLevelsOfSymlinks() int {
+	if f.MaxLevelsOfSymlinks == 0 {
+		return DEFAULT_MAX_LEVELS_OF_SYMLINKS
+	}
+	return f.MaxLevelsOfSymlinks
+}
+
+// Scan walks the fs.FS, entering only the directories that can hold included
+// names and whose contents are not all excluded. The error is that of an
+// invalid pattern or of reading a directory.
+func (f *FileSet) Scan() (*ScanResult, error) {
+	s, err := f.selector()
+	if err != nil {
+		return nil, err
+	}
+	scanner := &fileSetScanner{fileSet: f, selector: s, result: &ScanResult{}}
+	scanner.add(".", true)
+	if s.descend(".") {
+		if err := scanner.scan(".", 0); err != nil {
+			return nil, err
+		}
+	}
+	return scanner.result, nil
+}
+
+// Files returns the included files of the fs.FS.
+func (f *FileSet) Files() ([]string, error) {
+	result, err := f.Scan()
+	if err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+type fileSetScanner struct {
+	fileSet  *FileSet
+	selector *selector
+	result   *ScanResult
+	// visit, if not nil, is called for each selected name as it is added; its
+	// error stops the scan.
+	visit func(name string, isDir bool) error
+}
+
+// scan adds the entries of the directory dir, reached through levels
+// symbolic links.
+func (s *fileSetScanner) scan(dir string, levels int) error {
+	entries, err := fs.ReadDir(s.fileSet.FS, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := path.Join(dir, entry.Name())
+		isDir, nextLevels := entry.IsDir(), levels
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if s.fileSet.NoFollowSymlinks || levels >= s.fileSet.maxLevelsOfSymlinks() {
+				s.result.NotFollowedSymlinks = append(s.result.NotFollowedSymlinks, name)
+				continue
+			}
+			info, err := fs.Stat(s.fileSet.FS, name)
+			if err != nil {
+				// a broken link is neither a file nor a directory
+				continue
+			}
+			isDir, nextLevels = info.IsDir(), levels+1
+		}
+		if err := s.add(name, isDir); err != nil {
+			return err
+		}
+		if isDir && s.selector.descend(name) {
+			if err := s.scan(name, nextLevels); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fileSetScanner) add(name string, isDir bool) error {
+	if !s.selector.included(name) {
+		s.result.NotIncluded = append(s.result.NotIncluded, name)
+		return nil
+	}
+	if k := s.selector.excluded(name); k >= 0 {
+		s.result.Excluded = append(s.result.Excluded, Exclusion{
+			Name:    name,
+			Dir:     isDir,
+			Pattern: s.selector.rules[k],
+			Default: k >= len(s.selector.excludes)-s.selector.defaults,
+		})
+		return nil
+	}
+	if isDir {
+		s.result.Dirs = append(s.result.Dirs, name)
+	} else {
+		s.result.Files = append(s.result.Files, name)
+	}
+	if s.visit != nil {
+		return s.visit(name, isDir)
+	}
+	return nil
+}
+
+//endregion
@@ -0,0 +1,147 @@
+package antfs
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// @Author :George
+// @File: fileset_test
+// @Version: 1.0.0
+// @Date 2026/10/19 00:40
+
+// the zero value has the defaults of Ant
+func Test_fileSetDefaults(t *testing.T) {
+	e := assert.New(t)
+	fileSet := &FileSet{FS: testFS()}
+	result, err := fileSet.Scan()
+	e.NoError(err)
+	e.Equal(result.Files, []string{"README.md", "bin/app", "bin/app.debug", "configs/dev/app.yaml",
+		"configs/prod/app.yaml", "configs/prod/secrets/k.yaml", "docs/Guide.MD", "docs/guide/intro.md", "go.mod",
+		"src/main.go", "src/main_test.go", "src/util/deep/deep_test.go", "src/util/strings.go", "src/util/strings_test.go",
+		"static/app.css", "static/js/app.js", "static/js/app.js.map", "templates/drafts/old.tmpl",
+		"templates/index.tmpl", "templates/mail/README.md", "templates/mail/welcome.tmpl",
+		"vendor/lib/lib.go", "vendor/lib/lib_test.go"})
+	e.Equal(result.Dirs, []string{".", "bin", "configs", "configs/dev", "configs/prod", "configs/prod/secrets",
+		"docs", "docs/guide", "empty", "src", "src/util", "src/util/deep", "static", "static/js", "templates",
+		"templates/drafts", "templates/mail", "vendor", "vendor/lib"})
+	e.Equal(result.Excluded, []Exclusion{
+		{Name: ".git", Dir: true, Pattern: "**/.git", Default: true},
+		{Name: ".gitignore", Pattern: "**/.gitignore", Default: true},
+		{Name: "bin/app~", Pattern: "**/*~", Default: true},
+		{Name: "configs/.DS_Store", Pattern: "**/.DS_Store", Default: true},
+		{Name: "src/CVS", Dir: true, Pattern: "**/CVS", Default: true},
+	})
+	e.Empty(result.NotIncluded)
+
+	files, err := fileSet.Files()
+	e.NoError(err)
+	e.Equal(files, result.Files)
+}
+
+func Test_fileSetIncludesExcludes(t *testing.T) {
+	e := assert.New(t)
+	fileSet := NewFileSet(testFS())
+	fileSet.Includes = []string{"src/", "configs/**/*.yaml"}
+	fileSet.Excludes = []string{"**/*_test.go", "configs/prod/"}
+	result, err := fileSet.Scan()
+	e.NoError(err)
+	e.Equal(result.Files, []string{"configs/dev/app.yaml", "src/main.go", "src/util/strings.go"})
+	e.Equal(result.Dirs, []string{"src", "src/util", "src/util/deep"})
+	e.Equal(result.Excluded, []Exclusion{
+		{Name: "src/CVS", Dir: true, Pattern: "**/CVS", Default: true},
+		{Name: "src/main_test.go", Pattern: "**/*_test.go"},
+		{Name: "src/util/deep/deep_test.go", Pattern: "**/*_test.go"},
+		{Name: "src/util/strings_test.go", Pattern: "**/*_test.go"},
+	})
+	// neither the directories that cannot hold an include nor configs/prod,
+	// whose contents are excluded, are entered
+	e.Equal(result.NotIncluded, []string{".", ".git", ".gitignore", "README.md", "bin", "configs",
+		"configs/.DS_Store", "configs/dev", "configs/prod", "docs", "empty", "go.mod", "static", "templates", "vendor"})
+
+	fileSet.NoDefaultExcludes = true
+	result, err = fileSet.Scan()
+	e.NoError(err)
+	e.Equal(result.Files, []string{"configs/dev/app.yaml", "src/CVS/Entries", "src/main.go", "src/util/strings.go"})
+}
+
+func Test_fileSetExclusionPatternAsGiven(t *testing.T) {
+	e := assert.New(t)
+	fileSet := NewFileSet(testFS())
+	fileSet.Excludes = []string{"configs/", "/src/*.go"}
+	result, err := fileSet.Scan()
+	e.NoError(err)
+	var patterns []string
+	for _, exclusion := range result.Excluded {
+		if !exclusion.Default {
+			patterns = append(patterns, exclusion.Name+" "+exclusion.Pattern)
+		}
+	}
+	e.Equal(patterns, []string{"configs configs/", "src/main.go /src/*.go", "src/main_test.go /src/*.go"})
+}
+
+func Test_fileSetCaseSensitive(t *testing.T) {
+	e := assert.New(t)
+	fileSet := NewFileSet(testFS())
+	fileSet.Includes = []string{"DOCS/*.md"}
+	files, err := fileSet.Files()
+	e.NoError(err)
+	e.Empty(files)
+
+	fileSet.CaseInsensitive = true
+	files, err = fileSet.Files()
+	e.NoError(err)
+	e.Equal(files, []string{"docs/Guide.MD"})
+}
+
+func Test_fileSetErrors(t *testing.T) {
+	e := assert.New(t)
+	fileSet := NewFileSet(testFS())
+	fileSet.Excludes = []string{"{id:(a)}"}
+	_, err := fileSet.Scan()
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+
+	sub, err := fs.Sub(testFS(), "missing")
+	e.NoError(err)
+	_, err = NewFileSet(sub).Scan()
+	e.True(errors.Is(err, fs.ErrNotExist))
+}
+
+func Test_fileSetSymlinks(t *testing.T) {
+	e := assert.New(t)
+	dir := t.TempDir()
+	e.NoError(os.MkdirAll(filepath.Join(dir, "real", "sub"), 0o755))
+	e.NoError(os.WriteFile(filepath.Join(dir, "real", "sub", "a.txt"), nil, 0o644))
+	if err := os.Symlink("real", filepath.Join(dir, "link")); err != nil {
+		t.Skip("symbolic links are not supported:", err)
+	}
+	e.NoError(os.Symlink(filepath.Join("real", "sub", "a.txt"), filepath.Join(dir, "file.txt")))
+	// a loop: real/sub/up leads back to real
+	e.NoError(os.Symlink("..", filepath.Join(dir, "real", "sub", "up")))
+
+	fileSet := NewFileSet(os.DirFS(dir))
+	fileSet.Includes = []string{"**/*.txt"}
+	fileSet.MaxLevelsOfSymlinks = 2
+	result, err := fileSet.Scan()
+	e.NoError(err)
+	e.Equal(result.Files, []string{"file.txt", "link/sub/a.txt", "link/sub/up/sub/a.txt",
+		"real/sub/a.txt", "real/sub/up/sub/a.txt", "real/sub/up/sub/up/sub/a.txt"})
+	e.Equal(result.NotFollowedSymlinks, []string{"link/sub/up/sub/up", "real/sub/up/sub/up/sub/up"})
+
+	// the zero value follows DEFAULT_MAX_LEVELS_OF_SYMLINKS links
+	result, err = (&FileSet{FS: os.DirFS(dir), Includes: []string{"link/**/*.txt"}}).Scan()
+	e.NoError(err)
+	e.Len(result.Files, DEFAULT_MAX_LEVELS_OF_SYMLINKS)
+	e.Equal(result.Files[DEFAULT_MAX_LEVELS_OF_SYMLINKS-1], "link/sub/up/sub/up/sub/up/sub/up/sub/a.txt")
+
+	fileSet.NoFollowSymlinks = true
+	result, err = fileSet.Scan()
+	e.NoError(err)
+	e.Equal(result.Files, []string{"real/sub/a.txt"})
+	e.Equal(result.NotFollowedSymlinks, []string{"file.txt", "link", "real/sub/up"})
+}
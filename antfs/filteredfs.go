@@ -0,0 +1,195 @@
+package antfs
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"io"
+	"io/fs"
+	"path"
+)
+
+// @Author :George
+// @File: filteredfs
+// @Version: 1.0.0
+// @Date 2026/10/19 01:00
+
+//region filteredFS
+
+// filteredFS shows the names of an fs.FS that a selector selects. A directory
+// is shown when it is selected or may hold selected names and is not
+// excluded; a name is only shown if all its parent directories are.
+type filteredFS struct {
+	fsys     fs.FS
+	selector *selector
+}
+
+// NewFilteredFS returns a view of fsys showing only the files matching an
+// include pattern, any if includes is empty, and no exclude pattern, along with
+// the directories leading to them. Hidden names do not exist for Open, Stat,
+// ReadDir and Glob. It panics if a pattern is invalid.
+func NewFilteredFS(fsys fs.FS, includes, excludes []string, opts ...antpathmatcher.Option) fs.FS {
+	filtered, err := TryNewFilteredFS(fsys, includes, excludes, opts...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return filtered
+}
+
+func TryNewFilteredFS(fsys fs.FS, includes, excludes []string, opts ...antpathmatcher.Option) (fs.FS, error) {
+	s, err := newSelector(includes, excludes, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &filteredFS{fsys: fsys, selector: s}, nil
+}
+
+func (f *filteredFS) visible(name string, isDir bool) bool {
+	if name == "." {
+		return true
+	}
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if !f.dirVisible(dir) {
+			return false
+		}
+	}
+	if isDir {
+		return f.dirVisible(name)
+	}
+	return f.selector.selected(name)
+}
+
+func (f *filteredFS) dirVisible(name string) bool {
+	return f.selector.excluded(name) < 0 && (f.selector.included(name) || f.selector.descend(name))
+}
+
+func (f *filteredFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	file, err := f.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !f.visible(name, info.IsDir()) {
+		file.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if info.IsDir() {
+		return &filteredDir{File: file, fsys: f, name: name}, nil
+	}
+	return file, nil
+}
+
+func (f *filteredFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	info, err := fs.Stat(f.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if !f.visible(name, info.IsDir()) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return info, nil
+}
+
+func (f *filteredFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	if info, err := f.Stat(name); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	entries, err := fs.ReadDir(f.fsys, name)
+	return f.filter(name, entries), err
+}
+
+// Glob matches pattern, in the syntax of path.Match, against the names shown.
+func (f *filteredFS) Glob(pattern string) ([]string, error) {
+	names, err := fs.Glob(f.fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]string, 0, len(names))
+	for _, name := range names {
+		if info, err := fs.Stat(f.fsys, name); err == nil && f.visible(name, info.IsDir()) {
+			matches = append(matches, name)
+		}
+	}
+	return matches, nil
+}
+
+// filter returns the entries of the directory dir that are shown; dir itself
+// is. A symbolic link is shown like what it leads to, as Open and Stat do, and
+// a broken one not at all. entries is left as it is, since it may belong to
+// the underlying fs.FS.
+func (f *filteredFS) filter(dir string, entries []fs.DirEntry) []fs.DirEntry {
+	filtered := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		name := path.Join(dir, entry.Name())
+		isDir := entry.IsDir()
+		if entry.Type()&fs.ModeSymlink != 0 {
+			info, err := fs.Stat(f.fsys, name)
+			if err != nil {
+				continue
+			}
+			isDir = info.IsDir()
+		}
+		visible := f.selector.selected(name)
+		if isDir {
+			visible = f.dirVisible(name)
+		}
+		if visible {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+//endregion
+
+// filteredDir is an open directory of a filteredFS, hiding its entries too.
+type filteredDir struct {
+	fs.File
+	fsys    *filteredFS
+	name    string
+	pending []fs.DirEntry
+	eof     bool
+}
+
+func (d *filteredDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	dir, ok := d.File.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: errors.New("not implemented")}
+	}
+	if n <= 0 {
+		entries, err := dir.ReadDir(-1)
+		entries = append(d.pending, d.fsys.filter(d.name, entries)...)
+		d.pending, d.eof = nil, true
+		return entries, err
+	}
+	for len(d.pending) < n && !d.eof {
+		entries, err := dir.ReadDir(n)
+		d.pending = append(d.pending, d.fsys.filter(d.name, entries)...)
+		if err == io.EOF {
+			d.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if len(d.pending) == 0 {
+		return nil, io.EOF
+	}
+	k := min(n, len(d.pending))
+	entries := d.pending[:k:k]
+	d.pending = d.pending[k:]
+	return entries, nil
+}
@@ -0,0 +1,196 @@
+package antfs
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// @Author :George
+// @File: filteredfs_test
+// @Version: 1.0.0
+// @Date 2026/10/19 01:20
+
+func Test_filteredFS(t *testing.T) {
+	e := assert.New(t)
+	fsys := NewFilteredFS(testFS(), []string{"static/**", "templates/**/*.tmpl"}, []string{"**/*.map", "templates/drafts/"})
+	e.Implements((*fs.ReadDirFS)(nil), fsys)
+	e.Implements((*fs.StatFS)(nil), fsys)
+	e.Implements((*fs.GlobFS)(nil), fsys)
+	e.NoError(fstest.TestFS(fsys, "static/app.css", "static/js/app.js", "templates/index.tmpl", "templates/mail/welcome.tmpl"))
+
+	matches, err := Glob(fsys, "**")
+	e.NoError(err)
+	e.Equal(matches, []string{"static", "static/app.css", "static/js", "static/js/app.js",
+		"templates", "templates/index.tmpl", "templates/mail", "templates/mail/welcome.tmpl"})
+
+	for _, name := range []string{"go.mod", "configs", "configs/prod/secrets/k.yaml", "static/js/app.js.map", "templates/mail/README.md", "templates/drafts", "templates/drafts/old.tmpl"} {
+		_, err := fsys.Open(name)
+		e.True(errors.Is(err, fs.ErrNotExist), name)
+		_, err = fs.Stat(fsys, name)
+		e.True(errors.Is(err, fs.ErrNotExist), name)
+		_, err = fs.ReadFile(fsys, name)
+		e.True(errors.Is(err, fs.ErrNotExist), name)
+	}
+	_, err = fs.ReadDir(fsys, "configs")
+	e.True(errors.Is(err, fs.ErrNotExist))
+	_, err = fs.ReadDir(fsys, "static/app.css")
+	e.Error(err)
+	_, err = fsys.Open("../static")
+	e.True(errors.Is(err, fs.ErrInvalid))
+
+	data, err := fs.ReadFile(fsys, "templates/mail/welcome.tmpl")
+	e.NoError(err)
+	e.Equal(string(data), "welcome")
+
+	globbed, err := fs.Glob(fsys, "templates/*/*")
+	e.NoError(err)
+	e.Equal(globbed, []string{"templates/mail/welcome.tmpl"})
+}
+
+// cachingFS returns the same slices from ReadDir and Glob on every call, like
+// an fs.FS that caches its listings may.
+type cachingFS struct {
+	fs.FS
+	dirs  map[string][]fs.DirEntry
+	globs map[string][]string
+}
+
+func (c *cachingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if _, ok := c.dirs[name]; !ok {
+		entries, err := fs.ReadDir(c.FS, name)
+		if err != nil {
+			return nil, err
+		}
+		c.dirs[name] = entries
+	}
+	return c.dirs[name], nil
+}
+
+func (c *cachingFS) Glob(pattern string) ([]string, error) {
+	if _, ok := c.globs[pattern]; !ok {
+		names, err := fs.Glob(c.FS, pattern)
+		if err != nil {
+			return nil, err
+		}
+		c.globs[pattern] = names
+	}
+	return c.globs[pattern], nil
+}
+
+func Test_filteredFSLeavesListings(t *testing.T) {
+	e := assert.New(t)
+	cache := &cachingFS{FS: testFS(), dirs: make(map[string][]fs.DirEntry), globs: make(map[string][]string)}
+	fsys := NewFilteredFS(cache, []string{"templates/**/*.tmpl"}, nil)
+	for i := 0; i < 2; i++ {
+		entries, err := fs.ReadDir(fsys, "templates/mail")
+		e.NoError(err)
+		e.Len(entries, 1)
+		e.Equal(entries[0].Name(), "welcome.tmpl")
+		matches, err := fs.Glob(fsys, "templates/*/*")
+		e.NoError(err)
+		e.Equal(matches, []string{"templates/drafts/old.tmpl", "templates/mail/welcome.tmpl"})
+	}
+	e.Equal(cache.dirs["templates/mail"][0].Name(), "README.md")
+	e.Equal(cache.globs["templates/*/*"], []string{"templates/drafts/old.tmpl", "templates/mail/README.md", "templates/mail/welcome.tmpl"})
+}
+
+func Test_filteredFSSymlinks(t *testing.T) {
+	e := assert.New(t)
+	dir := t.TempDir()
+	e.NoError(os.MkdirAll(filepath.Join(dir, "assets"), 0o755))
+	e.NoError(os.WriteFile(filepath.Join(dir, "assets", "app.css"), []byte("body{}"), 0o644))
+	if err := os.Symlink("assets", filepath.Join(dir, "static")); err != nil {
+		t.Skip("symbolic links are not supported:", err)
+	}
+	e.NoError(os.Symlink("missing.css", filepath.Join(dir, "broken.css")))
+
+	// static is shown as the directory it leads to, broken.css not at all
+	fsys := NewFilteredFS(os.DirFS(dir), []string{"static/*.css", "*.css"}, nil)
+	entries, err := fs.ReadDir(fsys, ".")
+	e.NoError(err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	e.Equal(names, []string{"static"})
+	info, err := fs.Stat(fsys, "static")
+	e.NoError(err)
+	e.True(info.IsDir())
+	data, err := fs.ReadFile(fsys, "static/app.css")
+	e.NoError(err)
+	e.Equal(string(data), "body{}")
+	matches, err := fs.Glob(fsys, "*/*.css")
+	e.NoError(err)
+	e.Equal(matches, []string{"static/app.css"})
+}
+
+func Test_filteredFSReadDirInBatches(t *testing.T) {
+	e := assert.New(t)
+	fsys := NewFilteredFS(testFS(), []string{"templates/**"}, []string{"**/*.md"})
+	file, err := fsys.Open("templates")
+	e.NoError(err)
+	defer file.Close()
+	dir := file.(fs.ReadDirFile)
+	var names []string
+	for {
+		entries, err := dir.ReadDir(1)
+		if err == io.EOF {
+			e.Empty(entries)
+			break
+		}
+		e.NoError(err)
+		e.Len(entries, 1)
+		names = append(names, entries[0].Name())
+	}
+	e.Equal(names, []string{"drafts", "index.tmpl", "mail"})
+}
+
+func Test_filteredFSInvalidPattern(t *testing.T) {
+	e := assert.New(t)
+	_, err := TryNewFilteredFS(testFS(), []string{"{id:(a)}"}, nil)
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+	e.Panics(func() { NewFilteredFS(testFS(), nil, []string{"{id:(a)}"}) })
+}
+
+func Test_filteredFSCaseInsensitive(t *testing.T) {
+	e := assert.New(t)
+	fsys := NewFilteredFS(testFS(), []string{"STATIC/*.CSS"}, nil, antpathmatcher.WithCaseSensitive(false))
+	e.NoError(fstest.TestFS(fsys, "static/app.css"))
+	_, err := fs.Stat(fsys, "static/js")
+	e.True(errors.Is(err, fs.ErrNotExist))
+}
+
+func Test_filteredFSHttp(t *testing.T) {
+	e := assert.New(t)
+	server := http.FileServer(http.FS(NewFilteredFS(testFS(), []string{"static/**"}, nil)))
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/static/app.css", http.StatusOK, "body{}"},
+		{"/go.mod", http.StatusNotFound, ""},
+		{"/configs/prod/secrets/k.yaml", http.StatusNotFound, ""},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+		e.Equal(w.Code, test.status, test.path)
+		if test.body != "" {
+			e.Equal(w.Body.String(), test.body)
+		}
+	}
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	e.Contains(w.Body.String(), "static/")
+	e.NotContains(w.Body.String(), "go.mod")
+}
@@ -0,0 +1,47 @@
+package antfs
+
+import (
+	"io/fs"
+	"testing/fstest"
+)
+
+// @Author :George
+// @File: fixture_test
+// @Version: 1.0.0
+// @Date 2026/10/18 23:45
+
+// testFS returns the project tree the antfs tests share.
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		".git/HEAD":                   {},
+		".git/objects/ab/cdef":        {},
+		".gitignore":                  {},
+		"bin/app":                     {Data: []byte("binary")},
+		"bin/app.debug":               {Data: []byte("symbols")},
+		"bin/app~":                    {},
+		"go.mod":                      {},
+		"README.md":                   {},
+		"src/main.go":                 {},
+		"src/main_test.go":            {},
+		"src/CVS/Entries":             {},
+		"src/util/strings.go":         {},
+		"src/util/strings_test.go":    {},
+		"src/util/deep/deep_test.go":  {},
+		"vendor/lib/lib.go":           {},
+		"vendor/lib/lib_test.go":      {},
+		"docs/guide/intro.md":         {Data: []byte("# Intro")},
+		"docs/Guide.MD":               {},
+		"configs/.DS_Store":           {},
+		"configs/dev/app.yaml":        {Data: []byte("port: 80")},
+		"configs/prod/app.yaml":       {},
+		"configs/prod/secrets/k.yaml": {},
+		"empty":                       {Mode: fs.ModeDir},
+		"static/app.css":              {Data: []byte("body{}")},
+		"static/js/app.js":            {Data: []byte("main()")},
+		"static/js/app.js.map":        {Data: []byte("{}")},
+		"templates/index.tmpl":        {Data: []byte("index")},
+		"templates/mail/welcome.tmpl": {Data: []byte("welcome")},
+		"templates/mail/README.md":    {Data: []byte("notes")},
+		"templates/drafts/old.tmpl":   {Data: []byte("old")},
+	}
+}
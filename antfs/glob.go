@@ -0,0 +1,120 @@
+package antfs
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"io/fs"
+	"strings"
+)
+
+// @Author :George
+// @File: glob
+// @Version: 1.0.0
+// @Date 2026/10/18 23:40
+
+// SEPARATOR is the separator of the names of an fs.FS.
+const SEPARATOR = "/"
+
+// Glob returns the names of the files and directories of fsys matching
+// pattern, in lexical order. Names in an fs.FS are unrooted, so a leading "/"
+// of pattern is ignored. The only possible error is that of an invalid
+// pattern; I/O errors are ignored, like filepath.Glob does.
+func Glob(fsys fs.FS, pattern string, opts ...antpathmatcher.Option) ([]string, error) {
+	var matches []string
+	err := WalkMatches(fsys, pattern, func(name string, d fs.DirEntry, err error) error {
+		if err == nil {
+			matches = append(matches, name)
+		}
+		return nil
+	}, opts...)
+	return matches, err
+}
+
+// WalkMatches walks fsys like fs.WalkDir, calling fn only for the names that
+// match pattern and for the errors met on the way. The walk starts at the
+// literal base directory of pattern, "src" for "src/**/*_test.go", and skips
+// the directories that cannot contain a match according to MatchStart.
+func WalkMatches(fsys fs.FS, pattern string, fn fs.WalkDirFunc, opts ...antpathmatcher.Option) error {
+	p, err := compile(pattern, opts...)
+	if err != nil {
+		return err
+	}
+	return walkPattern(fsys, p, fn)
+}
+
+func compile(pattern string, opts ...antpathmatcher.Option) (*antpathmatcher.Pattern, error) {
+	// names of an fs.FS are always separated by "/"
+	opts = append(opts[:len(opts):len(opts)], antpathmatcher.WithPathSeparator(SEPARATOR))
+	return antpathmatcher.Compile(strings.TrimPrefix(pattern, SEPARATOR), opts...)
+}
+
+func walkPattern(fsys fs.FS, p *antpathmatcher.Pattern, fn fs.WalkDirFunc) error {
+	base, literal := baseDir(p)
+	if literal {
+		// nothing to walk, the pattern names one file
+		info, err := fs.Stat(fsys, p.String())
+		if err != nil {
+			return ignoreNotExist(err, func() error { return ignoreSkip(fn(p.String(), nil, err)) })
+		}
+		return ignoreSkip(fn(p.String(), fs.FileInfoToDirEntry(info), nil))
+	}
+	if _, err := fs.Stat(fsys, base); err != nil {
+		return ignoreNotExist(err, func() error { return ignoreSkip(fn(base, nil, err)) })
+	}
+	return fs.WalkDir(fsys, base, func(name string, d fs.DirEntry, err error) error {
+		if name == "." && err == nil {
+			return nil
+		}
+		if err != nil || p.Match(name) {
+			if err := fn(name, d, err); err != nil {
+				return err
+			}
+		}
+		if d != nil && d.IsDir() && !p.MatchStart(name) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+}
+
+// baseDir returns the directory made of the leading segments of p without
+// wildcards or variables, "." if there is none, and whether all of p is such
+// a literal. A case-insensitive pattern has no base directory, the case of
+// the names of fsys may differ from that of the pattern.
+func baseDir(p *antpathmatcher.Pattern) (string, bool) {
+	if !p.CaseSensitive() {
+		return ".", false
+	}
+	segments := strings.Split(p.String(), SEPARATOR)
+	k := 0
+	for k < len(segments) && isLiteral(segments[k]) {
+		k++
+	}
+	if k == len(segments) {
+		return "", fs.ValidPath(p.String())
+	}
+	if k == 0 {
+		return ".", false
+	}
+	return strings.Join(segments[:k], SEPARATOR), false
+}
+
+func isLiteral(segment string) bool {
+	return segment != "" && segment != "." && segment != ".." && !strings.ContainsAny(segment, "*?{")
+}
+
+// ignoreNotExist reports a missing start of the walk as no match, other
+// errors with report.
+func ignoreNotExist(err error, report func() error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return report()
+}
+
+func ignoreSkip(err error) error {
+	if err == fs.SkipDir || err == fs.SkipAll {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package antfs
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// @Author :George
+// @File: glob_test
+// @Version: 1.0.0
+// @Date 2026/10/18 23:50
+
+// countingFS records the directories read during a walk.
+type countingFS struct {
+	fs.FS
+	mu   sync.Mutex
+	read []string
+}
+
+func (c *countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	c.mu.Lock()
+	c.read = append(c.read, name)
+	c.mu.Unlock()
+	return fs.ReadDir(c.FS, name)
+}
+
+func Test_glob(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		pattern string
+		matches []string
+	}{
+		{"src/**/*_test.go", []string{"src/main_test.go", "src/util/deep/deep_test.go", "src/util/strings_test.go"}},
+		{"/src/**/*_test.go", []string{"src/main_test.go", "src/util/deep/deep_test.go", "src/util/strings_test.go"}},
+		{"**/*_test.go", []string{"src/main_test.go", "src/util/deep/deep_test.go", "src/util/strings_test.go", "vendor/lib/lib_test.go"}},
+		{"src/*.go", []string{"src/main.go", "src/main_test.go"}},
+		{"src/util", []string{"src/util"}},
+		{"src/util/strings.go", []string{"src/util/strings.go"}},
+		{"src/missing.go", nil},
+		{"missing/**", nil},
+		{"src/util/**", []string{"src/util", "src/util/deep", "src/util/deep/deep_test.go", "src/util/strings.go", "src/util/strings_test.go"}},
+		{"configs/*/app.yaml", []string{"configs/dev/app.yaml", "configs/prod/app.yaml"}},
+		{"configs/**/*.yaml", []string{"configs/dev/app.yaml", "configs/prod/app.yaml", "configs/prod/secrets/k.yaml"}},
+		{"configs/{env}/app.yaml", []string{"configs/dev/app.yaml", "configs/prod/app.yaml"}},
+		{"*", []string{".git", ".gitignore", "README.md", "bin", "configs", "docs", "empty", "go.mod", "src", "static", "templates", "vendor"}},
+		{"?o.mod", []string{"go.mod"}},
+		{"docs/**/*.md", []string{"docs/guide/intro.md"}},
+	}
+	for _, test := range tests {
+		matches, err := Glob(testFS(), test.pattern)
+		e.NoError(err, test.pattern)
+		e.Equal(matches, test.matches, test.pattern)
+	}
+}
+
+func Test_globCaseInsensitive(t *testing.T) {
+	e := assert.New(t)
+	matches, err := Glob(testFS(), "DOCS/**/*.md", antpathmatcher.WithCaseSensitive(false))
+	e.NoError(err)
+	e.Equal(matches, []string{"docs/Guide.MD", "docs/guide/intro.md"})
+}
+
+func Test_globInvalidPattern(t *testing.T) {
+	e := assert.New(t)
+	_, err := Glob(testFS(), "src/{id:(a)}")
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+}
+
+func Test_globAgreesWithMatch(t *testing.T) {
+	e := assert.New(t)
+	fsys := testFS()
+	for _, pattern := range []string{"**", "**/*.go", "src/**", "*/*/*.go", "**/lib/**", "docs/*", "c*/**/app.yaml"} {
+		var expected []string
+		e.NoError(fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if name != "." && antpathmatcher.MustCompile(pattern).Match(name) {
+				expected = append(expected, name)
+			}
+			return err
+		}))
+		sort.Strings(expected)
+		matches, err := Glob(fsys, pattern)
+		e.NoError(err)
+		e.Equal(matches, expected, pattern)
+	}
+}
+
+func Test_walkMatchesPrunes(t *testing.T) {
+	e := assert.New(t)
+	fsys := &countingFS{FS: testFS()}
+	matches, err := Glob(fsys, "src/**/*_test.go")
+	e.NoError(err)
+	e.Len(matches, 3)
+	e.Equal(fsys.read, []string{"src", "src/CVS", "src/util", "src/util/deep"})
+
+	fsys.read = nil
+	_, err = Glob(fsys, "*/lib/*.go")
+	e.NoError(err)
+	e.Equal(fsys.read, []string{".", ".git", "bin", "configs", "docs", "empty", "src", "static", "templates", "vendor", "vendor/lib"})
+
+	fsys.read = nil
+	_, err = Glob(fsys, "configs/prod/app.yaml")
+	e.NoError(err)
+	e.Empty(fsys.read)
+}
+
+func Test_walkMatchesSkip(t *testing.T) {
+	e := assert.New(t)
+	var names []string
+	e.NoError(WalkMatches(testFS(), "src/**", func(name string, d fs.DirEntry, err error) error {
+		names = append(names, name)
+		if name == "src/util" {
+			return fs.SkipDir
+		}
+		return err
+	}))
+	e.Equal(names, []string{"src", "src/CVS", "src/CVS/Entries", "src/main.go", "src/main_test.go", "src/util"})
+
+	names = nil
+	e.NoError(WalkMatches(testFS(), "**/*.go", func(name string, d fs.DirEntry, err error) error {
+		names = append(names, name)
+		return fs.SkipAll
+	}))
+	e.Equal(names, []string{"src/main.go"})
+
+	stop := errors.New("stop")
+	e.Equal(WalkMatches(testFS(), "src/main.go", func(name string, d fs.DirEntry, err error) error {
+		e.False(d.IsDir())
+		return stop
+	}), stop)
+}
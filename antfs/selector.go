@@ -0,0 +1,209 @@
+package antfs
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"io/fs"
+	"strings"
+)
+
+// @Author :George
+// @File: selector
+// @Version: 1.0.0
+// @Date 2026/10/19 00:10
+
+// selector decides which names of an fs.FS are selected by include and
+// exclude patterns, following the rules of the DirectoryScanner of Apache Ant.
+type selector struct {
+	includes []*antpathmatcher.Pattern
+	excludes []*antpathmatcher.Pattern
+	// rules are the exclude patterns as given, before normalizePattern
+	rules []string
+	// defaults is the number of excludes, at the end, that are default excludes
+	defaults int
+	// contents are the directories whose contents excludes ending with "/**"
+	// exclude
+	contents []*antpathmatcher.Pattern
+}
+
+// newSelector compiles includes, "**" if there are none, and excludes
+// followed by defaultExcludes.
+func newSelector(includes, excludes, defaultExcludes []string, opts ...antpathmatcher.Option) (*selector, error) {
+	if len(includes) == 0 {
+		includes = []string{"**"}
+	}
+	s := &selector{defaults: len(defaultExcludes)}
+	for _, include := range includes {
+		p, err := compile(normalizePattern(include), opts...)
+		if err != nil {
+			return nil, err
+		}
+		s.includes = append(s.includes, p)
+	}
+	s.rules = append(excludes[:len(excludes):len(excludes)], defaultExcludes...)
+	for _, exclude := range s.rules {
+		exclude = normalizePattern(exclude)
+		p, err := compile(exclude, opts...)
+		if err != nil {
+			return nil, err
+		}
+		s.excludes = append(s.excludes, p)
+		if exclude == "**" {
+			s.contents = append(s.contents, p)
+		} else if prefix, ok := strings.CutSuffix(exclude, SEPARATOR+"**"); ok {
+			if p, err = compile(prefix, opts...); err != nil {
+				return nil, err
+			}
+			s.contents = append(s.contents, p)
+		}
+	}
+	return s, nil
+}
+
+// normalizePattern separates the segments of pattern with "/" and, like Ant,
+// reads a trailing "/" as "/**".
+func normalizePattern(pattern string) string {
+	pattern = strings.ReplaceAll(pattern, "\\", SEPARATOR)
+	if strings.HasSuffix(pattern, SEPARATOR) {
+		pattern += "**"
+	}
+	return pattern
+}
+
+// matchName is the name the patterns see: "" for the root of the fs.FS.
+func matchName(name string) string {
+	if name == "." {
+		return ""
+	}
+	return name
+}
+
+func (s *selector) included(name string) bool {
+	name = matchName(name)
+	for _, p := range s.includes {
+		if p.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// excluded returns the index of the first exclude pattern matching name, -1
+// if there is none.
+func (s *selector) excluded(name string) int {
+	name = matchName(name)
+	for k, p := range s.excludes {
+		if p.Match(name) {
+			return k
+		}
+	}
+	return -1
+}
+
+// selected reports whether name is included and not excluded.
+func (s *selector) selected(name string) bool {
+	return s.included(name) && s.excluded(name) < 0
+}
+
+// couldHoldIncluded reports whether the directory name may contain included
+// names.
+func (s *selector) couldHoldIncluded(name string) bool {
+	name = matchName(name)
+	for _, p := range s.includes {
+		if p.MatchStart(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// contentsExcluded reports whether everything within the directory name is
+// excluded, as it is by "**/.git/**" for ".git".
+func (s *selector) contentsExcluded(name string) bool {
+	name = matchName(name)
+	for _, p := range s.contents {
+		if p.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// descend reports whether a walk must enter the directory name.
+func (s *selector) descend(name string) bool {
+	return s.couldHoldIncluded(name) && !s.contentsExcluded(name)
+}
+
+// walkSelected calls fn for the selected names of fsys, but ".", without
+// entering the directories that descend rules out. The errors of the walk
+// stop it, unless onError, if not nil, returns nil for them.
+func walkSelected(fsys fs.FS, s *selector, fn func(name string, d fs.DirEntry) error, onError func(name string, err error) error) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil && onError != nil {
+			return onError(name, err)
+		}
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			if !s.descend(name) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if s.selected(name) {
+			if err := fn(name, d); err != nil {
+				return err
+			}
+		}
+		if d.IsDir() && !s.descend(name) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+}
+
+// entrySelector selects the entries of an archive, listed in any order. It
+// remembers which directories descend rules out, so that the entries below
+// them are dropped without matching them.
+type entrySelector struct {
+	*selector
+	descends map[string]bool
+}
+
+func newEntrySelector(s *selector) *entrySelector {
+	return &entrySelector{selector: s, descends: make(map[string]bool)}
+}
+
+// selectEntry returns the name of the entry as an fs.FS would, without a
+// leading "./" or "/" or a trailing "/", and whether it is selected. Names
+// that are not valid in an fs.FS then, such as those with ".." elements,
+// are never selected: extracting them could write outside of the target.
+func (s *entrySelector) selectEntry(name string) (string, bool) {
+	name = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(name, "./"), SEPARATOR), SEPARATOR)
+	if name == "" {
+		return ".", false
+	}
+	if !fs.ValidPath(name) {
+		return name, false
+	}
+	for k := strings.Index(name, SEPARATOR); k >= 0; k = nextSeparator(name, k) {
+		dir := name[:k]
+		descend, ok := s.descends[dir]
+		if !ok {
+			descend = s.descend(dir)
+			s.descends[dir] = descend
+		}
+		if !descend {
+			return name, false
+		}
+	}
+	return name, s.selected(name)
+}
+
+func nextSeparator(name string, k int) int {
+	next := strings.Index(name[k+1:], SEPARATOR)
+	if next < 0 {
+		return -1
+	}
+	return k + 1 + next
+}
@@ -0,0 +1,171 @@
+package antfs
+
+import (
+	"context"
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"io/fs"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// @Author :George
+// @File: watcher
+// @Version: 1.0.0
+// @Date 2026/10/19 02:20
+
+const DEFAULT_POLL_INTERVAL = time.Second
+
+// Op is the change an Event reports.
+type Op int
+
+const (
+	CREATED Op = iota
+	MODIFIED
+	REMOVED
+)
+
+func (o Op) String() string {
+	switch o {
+	case CREATED:
+		return "CREATED"
+	case MODIFIED:
+		return "MODIFIED"
+	case REMOVED:
+		return "REMOVED"
+	default:
+		return "Op(" + strconv.Itoa(int(o)) + ")"
+	}
+}
+
+type Event struct {
+	Name string
+	Op   Op
+}
+
+func (e Event) String() string {
+	return e.Op.String() + " " + e.Name
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+//region Watcher
+
+// Watcher reports the changes to the files of an fs.FS selected by include
+// and exclude patterns by comparing snapshots of their modification time and
+// size, taken without entering the directories that cannot hold a selected
+// file. It is safe for concurrent use.
+type Watcher struct {
+	fsys     fs.FS
+	selector *selector
+	// Interval is the time between two polls of Run, DEFAULT_POLL_INTERVAL if
+	// it is not positive. Set it before calling Run.
+	Interval time.Duration
+	mu       sync.Mutex
+	snapshot map[string]fileState
+}
+
+// NewWatcher takes the first snapshot of fsys, the one the first Poll
+// compares with. The error is that of an invalid pattern or of the scan. A
+// scan fails when the root of fsys cannot be read, but not when a directory
+// below it is removed during the scan.
+func NewWatcher(fsys fs.FS, includes, excludes []string, opts ...antpathmatcher.Option) (*Watcher, error) {
+	s, err := newSelector(includes, excludes, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{fsys: fsys, selector: s, Interval: DEFAULT_POLL_INTERVAL}
+	if w.snapshot, err = w.scan(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Watcher) scan() (map[string]fileState, error) {
+	snapshot := make(map[string]fileState)
+	err := walkSelected(w.fsys, w.selector, func(name string, d fs.DirEntry) error {
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			// removed since the directory was read
+			return nil
+		}
+		snapshot[name] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	}, func(name string, err error) error {
+		if name != "." && errors.Is(err, fs.ErrNotExist) {
+			// removed since its parent was read
+			return nil
+		}
+		return err
+	})
+	return snapshot, err
+}
+
+// Poll takes a new snapshot and returns the changes since the previous one,
+// ordered by name.
+func (w *Watcher) Poll() ([]Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	snapshot, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for name, state := range snapshot {
+		previous, ok := w.snapshot[name]
+		if !ok {
+			events = append(events, Event{Name: name, Op: CREATED})
+		} else if !previous.modTime.Equal(state.modTime) || previous.size != state.size {
+			events = append(events, Event{Name: name, Op: MODIFIED})
+		}
+	}
+	for name := range w.snapshot {
+		if _, ok := snapshot[name]; !ok {
+			events = append(events, Event{Name: name, Op: REMOVED})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+	w.snapshot = snapshot
+	return events, nil
+}
+
+// Run polls every Interval and sends the events to events until ctx is done,
+// returning ctx.Err(), or a poll fails, returning its error.
+func (w *Watcher) Run(ctx context.Context, events chan<- Event) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DEFAULT_POLL_INTERVAL
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		polled, err := w.Poll()
+		if err != nil {
+			return err
+		}
+		for _, event := range polled {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+//endregion
@@ -0,0 +1,149 @@
+package antfs
+
+import (
+	"context"
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// @Author :George
+// @File: watcher_test
+// @Version: 1.0.0
+// @Date 2026/10/19 02:40
+
+func Test_watcherPoll(t *testing.T) {
+	e := assert.New(t)
+	now := time.Now()
+	fsys := fstest.MapFS{
+		"main.go":              {Data: []byte("package main"), ModTime: now},
+		"README.md":            {Data: []byte("readme"), ModTime: now},
+		"configs/dev/app.yaml": {Data: []byte("a: 1"), ModTime: now},
+		"node_modules/x/x.js":  {Data: []byte("x"), ModTime: now},
+	}
+	w, err := NewWatcher(fsys, []string{"**/*.go", "configs/**/*.yaml"}, []string{"vendor/"})
+	e.NoError(err)
+	events, err := w.Poll()
+	e.NoError(err)
+	e.Empty(events)
+
+	fsys["main.go"] = &fstest.MapFile{Data: []byte("package main"), ModTime: now.Add(time.Second)}
+	fsys["configs/dev/app.yaml"] = &fstest.MapFile{Data: []byte("a: 12"), ModTime: now}
+	fsys["configs/prod/app.yaml"] = &fstest.MapFile{Data: []byte("a: 2"), ModTime: now}
+	fsys["util/util.go"] = &fstest.MapFile{Data: []byte("package util"), ModTime: now}
+	fsys["vendor/lib/lib.go"] = &fstest.MapFile{Data: []byte("package lib"), ModTime: now}
+	fsys["README.md"] = &fstest.MapFile{Data: []byte("changed"), ModTime: now.Add(time.Second)}
+	events, err = w.Poll()
+	e.NoError(err)
+	e.Equal(events, []Event{
+		{Name: "configs/dev/app.yaml", Op: MODIFIED},
+		{Name: "configs/prod/app.yaml", Op: CREATED},
+		{Name: "main.go", Op: MODIFIED},
+		{Name: "util/util.go", Op: CREATED},
+	})
+
+	delete(fsys, "util/util.go")
+	delete(fsys, "configs/dev/app.yaml")
+	events, err = w.Poll()
+	e.NoError(err)
+	e.Equal(events, []Event{{Name: "configs/dev/app.yaml", Op: REMOVED}, {Name: "util/util.go", Op: REMOVED}})
+	e.Equal(events[0].String(), "REMOVED configs/dev/app.yaml")
+	e.Equal(Op(9).String(), "Op(9)")
+}
+
+func Test_watcherPrunes(t *testing.T) {
+	e := assert.New(t)
+	fsys := &countingFS{FS: fstest.MapFS{
+		"configs/dev/app.yaml": {},
+		"docs/a/b/c.md":        {},
+		"vendor/lib/lib.yaml":  {},
+	}}
+	w, err := NewWatcher(fsys, []string{"configs/**/*.yaml"}, nil)
+	e.NoError(err)
+	fsys.read = nil
+	_, err = w.Poll()
+	e.NoError(err)
+	e.Equal(fsys.read, []string{".", "configs", "configs/dev"})
+}
+
+// failingFS fails to read the directory dir with err, fs.ErrNotExist as if
+// it was removed after its parent was read.
+type failingFS struct {
+	fstest.MapFS
+	dir string
+	err error
+}
+
+func (f failingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == f.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: f.err}
+	}
+	return f.MapFS.ReadDir(name)
+}
+
+func Test_watcherRemovedDirectory(t *testing.T) {
+	e := assert.New(t)
+	mapFS := fstest.MapFS{
+		"a/keep.go":      {},
+		"a/gone/gone.go": {},
+	}
+	w, err := NewWatcher(mapFS, []string{"**/*.go"}, nil)
+	e.NoError(err)
+
+	fsys := failingFS{MapFS: mapFS, dir: "a/gone", err: fs.ErrNotExist}
+	w.fsys = fsys
+	events, err := w.Poll()
+	e.NoError(err)
+	e.Equal(events, []Event{{Name: "a/gone/gone.go", Op: REMOVED}})
+
+	w, err = NewWatcher(fsys, []string{"**/*.go"}, nil)
+	e.NoError(err)
+	events, err = w.Poll()
+	e.NoError(err)
+	e.Empty(events)
+
+	// other errors, and a missing root, still fail the scan
+	_, err = NewWatcher(failingFS{MapFS: mapFS, dir: "a/gone", err: fs.ErrPermission}, nil, nil)
+	e.True(errors.Is(err, fs.ErrPermission))
+	_, err = NewWatcher(failingFS{MapFS: mapFS, dir: ".", err: fs.ErrNotExist}, nil, nil)
+	e.True(errors.Is(err, fs.ErrNotExist))
+}
+
+func Test_newWatcherErrors(t *testing.T) {
+	e := assert.New(t)
+	_, err := NewWatcher(fstest.MapFS{}, []string{"{id:(a)}"}, nil)
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+	_, err = NewWatcher(os.DirFS(filepath.Join(t.TempDir(), "missing")), nil, nil)
+	e.True(errors.Is(err, os.ErrNotExist))
+}
+
+func Test_watcherRun(t *testing.T) {
+	e := assert.New(t)
+	dir := t.TempDir()
+	w, err := NewWatcher(os.DirFS(dir), []string{"**/*.go"}, nil)
+	e.NoError(err)
+	w.Interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan Event)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx, events)
+	}()
+	e.NoError(os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644))
+	e.NoError(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0o644))
+	select {
+	case event := <-events:
+		e.Equal(event, Event{Name: "main.go", Op: CREATED})
+	case <-time.After(5 * time.Second):
+		e.Fail("no event")
+	}
+	cancel()
+	e.Equal(<-done, context.Canceled)
+}
@@ -2,11 +2,12 @@ package antpathmatcher
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/georgeJobs/go-antpathmatcher/pkg"
-	"gopkg.in/guregu/null.v3"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // @Author :George
@@ -17,19 +18,35 @@ import (
 const DEFAULT_PATH_SEPARATOR = "/"
 const CACHE_TURNOFF_THRESHOLD = 65536
 
+// cacheTurnoffThreshold is what the caches compare against, so that tests can
+// reach it without tens of thousands of patterns.
+var cacheTurnoffThreshold = CACHE_TURNOFF_THRESHOLD
+
+// PATH_SEGMENTS_BUFFER is how many path and pattern segments are matched
+// without a heap allocation; deeper ones still match, they just spill to the
+// heap.
+const PATH_SEGMENTS_BUFFER = 32
+
 var WILDCARD_CHARS = [3]byte{'*', '?', '{'}
 var VARIABLE_PATTERN = regexp.MustCompile("\\{[^/]+?\\}")
 
 //region AntPathMatcher
 
+// AntPathMatcher is safe for concurrent use. Its configuration and pattern
+// caches live in an immutable matcherState that every call loads once; the
+// setters publish a fresh state instead of mutating the current one.
 type AntPathMatcher struct {
-	caseSensitive             bool
-	trimTokens                bool
-	cachePatterns             null.Bool
-	pathSeparator             string
+	mu    sync.Mutex
+	state atomic.Value
+}
+
+type matcherState struct {
+	options
+	owner                     *AntPathMatcher
 	pathSeparatorPatternCache *PathSeparatorPatternCache
-	tokenizedPatternCache     pkg.MySyncMap
-	stringMatcherCache        pkg.MySyncMap
+	tokenizedPatternCache     PatternCache
+	stringMatcherCache        PatternCache
+	counters                  *cacheCounters
 }
 
 func NewAntPathMatcher() *AntPathMatcher {
@@ -37,21 +54,117 @@ func NewAntPathMatcher() *AntPathMatcher {
 }
 
 func NewAntPathMatcherWithPathSeparator(pathSeparator string) *AntPathMatcher {
-	if strings.TrimSpace(pathSeparator) == "" {
-		pathSeparator = DEFAULT_PATH_SEPARATOR
+	return newAntPathMatcher(newOptions(WithPathSeparator(pathSeparator)))
+}
+
+func NewAntPathMatcherWithOptions(opts ...Option) *AntPathMatcher {
+	return newAntPathMatcher(newOptions(opts...))
+}
+
+func newAntPathMatcher(o options) *AntPathMatcher {
+	a := &AntPathMatcher{}
+	a.state.Store(newMatcherState(a, o, nil))
+	return a
+}
+
+func newMatcherState(owner *AntPathMatcher, o options, counters *cacheCounters) *matcherState {
+	if counters == nil {
+		counters = &cacheCounters{}
 	}
-	return &AntPathMatcher{
-		caseSensitive:             true,
-		trimTokens:                false,
-		pathSeparator:             pathSeparator,
-		pathSeparatorPatternCache: NewPathSeparatorPatternCache(pathSeparator),
-		tokenizedPatternCache:     pkg.MySyncMap{},
-		stringMatcherCache:        pkg.MySyncMap{},
+	newCache := o.newPatternCache
+	if newCache == nil {
+		newCache = newSyncMapPatternCache
 	}
+	return &matcherState{
+		options:                   o,
+		owner:                     owner,
+		pathSeparatorPatternCache: NewPathSeparatorPatternCache(o.pathSeparator),
+		tokenizedPatternCache:     newCache(),
+		stringMatcherCache:        newCache(),
+		counters:                  counters,
+	}
+}
+
+// next builds the state replacing s, carrying the cache statistics over.
+func (s *matcherState) next(o options) *matcherState {
+	s.counters.retire(s)
+	return newMatcherState(s.owner, o, s.counters)
+}
+
+// adaptiveCache reports whether the cache should be turned off once it grows
+// past CACHE_TURNOFF_THRESHOLD: only the default cache with no explicit
+// cachePatterns setting is.
+func (s *matcherState) adaptiveCache() bool {
+	return !s.cachePatterns.Valid && s.newPatternCache == nil
+}
+
+func (a *AntPathMatcher) load() *matcherState {
+	if s, ok := a.state.Load().(*matcherState); ok {
+		return s
+	}
+	// zero value AntPathMatcher, initialize it with the defaults
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if s, ok := a.state.Load().(*matcherState); ok {
+		return s
+	}
+	s := newMatcherState(a, newOptions(), nil)
+	a.state.Store(s)
+	return s
+}
+
+// update publishes a new state built from the current options. The caches are
+// always started afresh because they bake in the configuration.
+func (a *AntPathMatcher) update(opt Option) {
+	a.load()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	current := a.state.Load().(*matcherState)
+	o := current.options
+	opt(&o)
+	a.state.Store(current.next(o))
 }
 
 func (a *AntPathMatcher) SetCachePatterns(cachePatterns bool) {
-	a.cachePatterns.SetValid(cachePatterns)
+	a.update(WithCachePatterns(cachePatterns))
+}
+
+func (a *AntPathMatcher) SetCaseSensitive(caseSensitive bool) {
+	a.update(WithCaseSensitive(caseSensitive))
+}
+
+func (a *AntPathMatcher) SetTrimTokens(trimTokens bool) {
+	a.update(WithTrimTokens(trimTokens))
+}
+
+func (a *AntPathMatcher) SetDefaultVariablePattern(variablePattern string) {
+	a.update(WithDefaultVariablePattern(variablePattern))
+}
+
+func (a *AntPathMatcher) SetPathSeparator(pathSeparator string) {
+	a.update(WithPathSeparator(pathSeparator))
+}
+
+func (a *AntPathMatcher) DeactivatePatternCache() {
+	a.update(WithCachePatterns(false))
+}
+
+// deactivatePatternCache turns the cache off on behalf of a state that hit
+// CACHE_TURNOFF_THRESHOLD, unless the configuration has changed in the meantime.
+func (s *matcherState) deactivatePatternCache() {
+	a := s.owner
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.state.Load() != s {
+		return
+	}
+	o := s.options
+	o.cachePatterns.SetValid(false)
+	o.autoDeactivated = true
+	a.state.Store(s.next(o))
 }
 
 func (a *AntPathMatcher) IsPattern(path string) bool {
@@ -69,88 +182,121 @@ func (a *AntPathMatcher) IsPattern(path string) bool {
 	return false
 }
 func (a *AntPathMatcher) Match(pattern, path string) bool {
-	return a.doMatch(pattern, path, true, nil)
+	return a.load().doMatch(pattern, path, true, nil)
+}
+func (a *AntPathMatcher) TryMatch(pattern, path string) (bool, error) {
+	return a.load().tryDoMatch(pattern, path, true, nil)
 }
 func (a *AntPathMatcher) MatchStart(pattern, path string) bool {
-	return a.doMatch(pattern, path, false, nil)
+	return a.load().doMatch(pattern, path, false, nil)
+}
+func (a *AntPathMatcher) TryMatchStart(pattern, path string) (bool, error) {
+	return a.load().tryDoMatch(pattern, path, false, nil)
 }
 func (a *AntPathMatcher) ExtractPathWithinPattern(pattern, path string) string {
-	patternParts := pkg.TokenizeToStringArray(pattern, a.pathSeparator, a.trimTokens, true)
-	pathParts := pkg.TokenizeToStringArray(path, a.pathSeparator, a.trimTokens, true)
-	builder := bytes.NewBufferString("")
-	pathStarted := false
-	for segment := 0; segment < len(patternParts); segment++ {
-		patternPart := patternParts[segment]
-		if strings.IndexByte(patternPart, '*') > -1 || strings.IndexByte(patternPart, '?') > -1 {
-			for ; segment < len(pathParts); segment++ {
-				if pathStarted || segment == 0 && !strings.HasPrefix(pattern, a.pathSeparator) {
-					builder.WriteString(a.pathSeparator)
-				}
-				builder.WriteString(pathParts[segment])
-				pathStarted = true
-			}
-		}
-	}
-	return builder.String()
+	s := a.load()
+	return s.extractPathWithinPattern(pattern, s.tokenizePath(pattern), path)
 }
 func (a *AntPathMatcher) ExtractUriTemplateVariables(pattern, path string) map[string]string {
-	variables := make(map[string]string)
-	result := a.doMatch(pattern, path, true, variables)
-	if !result {
-		panic("Pattern \"" + pattern + "\" is not a match for \"" + path + "\"")
+	variables, err := a.TryExtractUriTemplateVariables(pattern, path)
+	if err != nil {
+		panic(err.Error())
 	}
 	return variables
 }
-
-func (a *AntPathMatcher) concat(path1, path2 string) string {
-	path1EndsWithSeparator := strings.HasSuffix(path1, a.pathSeparator)
-	path2StartsWithSeparator := strings.HasPrefix(path2, a.pathSeparator)
-
-	if path1EndsWithSeparator && path2StartsWithSeparator {
-		return path1 + path2[1:]
-	} else if path1EndsWithSeparator || path2StartsWithSeparator {
-		return path1 + path2
-	} else {
-		return path1 + a.pathSeparator + path2
+func (a *AntPathMatcher) TryExtractUriTemplateVariables(pattern, path string) (map[string]string, error) {
+	variables := make(map[string]string)
+	result, err := a.load().tryDoMatch(pattern, path, true, variables)
+	if err != nil {
+		return nil, err
 	}
+	if !result {
+		return nil, newError(ErrNoMatch, "Pattern \""+pattern+"\" is not a match for \""+path+"\"")
+	}
+	return variables, nil
 }
-
 func (a *AntPathMatcher) GetPatternComparator(path string) Comparator {
 	return NewAntPatternComparator(path)
 }
+
+// SortPatterns sorts patterns from the most to the least specific for path,
+// per GetPatternComparator. Equally specific patterns keep their order.
+func (a *AntPathMatcher) SortPatterns(path string, patterns []string) {
+	comparator := a.GetPatternComparator(path)
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return comparator.Compare(patterns[i], patterns[j]) < 0
+	})
+}
+
+// BestMatch returns the most specific of the patterns that match path, the
+// first one given if several are equally specific, along with its URI
+// template variables. Like Match, it panics on an invalid pattern.
+func (a *AntPathMatcher) BestMatch(path string, patterns []string) (string, map[string]string, bool) {
+	s := a.load()
+	comparator := a.GetPatternComparator(path)
+	best, found := "", false
+	for _, pattern := range patterns {
+		if s.doMatch(pattern, path, true, nil) && (!found || comparator.Compare(pattern, best) < 0) {
+			best, found = pattern, true
+		}
+	}
+	if !found {
+		return "", nil, false
+	}
+	variables := make(map[string]string)
+	s.doMatch(best, path, true, variables)
+	return best, variables, true
+}
 func (a *AntPathMatcher) Combine(pattern1, pattern2 string) string {
+	combined, err := a.TryCombine(pattern1, pattern2)
+	if err != nil {
+		panic(err.Error())
+	}
+	return combined
+}
+func (a *AntPathMatcher) TryCombine(pattern1, pattern2 string) (string, error) {
+	return a.load().tryCombine(pattern1, pattern2)
+}
+
+func (s *matcherState) tryCombine(pattern1, pattern2 string) (string, error) {
 	if !pkg.HasText(pattern1) && !pkg.HasText(pattern2) {
-		return ""
+		return "", nil
 	}
 	if !pkg.HasText(pattern1) {
-		return pattern2
+		return pattern2, nil
 	}
 	if !pkg.HasText(pattern2) {
-		return pattern1
+		return pattern1, nil
 	}
 
 	pattern1ContainsUriVar := strings.IndexByte(pattern1, '{') != -1
-	if pattern1 != pattern2 && !pattern1ContainsUriVar && a.Match(pattern1, pattern2) {
-		// /* + /hotel -> /hotel ; "/*.*" + "/*.html" -> /*.html
-		// However /user + /user -> /usr/user ; /{foo} + /bar -> /{foo}/bar
-		return pattern2
+	if pattern1 != pattern2 && !pattern1ContainsUriVar {
+		matched, err := s.tryDoMatch(pattern1, pattern2, true, nil)
+		if err != nil {
+			return "", err
+		}
+		if matched {
+			// /* + /hotel -> /hotel ; "/*.*" + "/*.html" -> /*.html
+			// However /user + /user -> /usr/user ; /{foo} + /bar -> /{foo}/bar
+			return pattern2, nil
+		}
 	}
 
 	// /hotels/* + /booking -> /hotels/booking
 	// /hotels/* + booking -> /hotels/booking
-	if strings.HasSuffix(pattern1, a.pathSeparatorPatternCache.endsOnWildcard) {
-		return a.concat(pattern1[:len(pattern1)-2], pattern2)
+	if strings.HasSuffix(pattern1, s.pathSeparatorPatternCache.endsOnWildcard) {
+		return s.concat(pattern1[:len(pattern1)-2], pattern2), nil
 	}
 	// /hotels/** + /booking -> /hotels/**/booking
 	// /hotels/** + booking -> /hotels/**/booking
-	if strings.HasSuffix(pattern1, a.pathSeparatorPatternCache.endsOnDoubleWildcard) {
-		return a.concat(pattern1, pattern2)
+	if strings.HasSuffix(pattern1, s.pathSeparatorPatternCache.endsOnDoubleWildcard) {
+		return s.concat(pattern1, pattern2), nil
 	}
 
 	starDotPos1 := strings.Index(pattern1, "*.")
-	if pattern1ContainsUriVar || starDotPos1 == -1 || a.pathSeparator == "." {
+	if pattern1ContainsUriVar || starDotPos1 == -1 || s.pathSeparator == "." {
 		// simply concatenate the two patterns
-		return a.concat(pattern1, pattern2)
+		return s.concat(pattern1, pattern2), nil
 	}
 
 	ext1 := pattern1[starDotPos1+1:]
@@ -166,26 +312,89 @@ func (a *AntPathMatcher) Combine(pattern1, pattern2 string) string {
 	ext2All := ext2 == ".*" || len(ext2) == 0
 
 	if !ext1All && !ext2All {
-		panic("Cannot combine patterns: " + pattern1 + " vs " + pattern2)
+		return "", newError(ErrIncompatibleExtensions, "Cannot combine patterns: "+pattern1+" vs "+pattern2)
 	}
 	ext := ext1
 	if ext1All {
 		ext = ext2
 	}
-	return file2 + ext
+	return file2 + ext, nil
 }
 
-func (a *AntPathMatcher) doMatch(pattern, path string, fullMatch bool, uriTemplateVariables map[string]string) bool {
-	//todo path is null
-	if strings.HasPrefix(path, a.pathSeparator) != strings.HasPrefix(pattern, a.pathSeparator) {
-		return false
+func (s *matcherState) concat(path1, path2 string) string {
+	path1EndsWithSeparator := strings.HasSuffix(path1, s.pathSeparator)
+	path2StartsWithSeparator := strings.HasPrefix(path2, s.pathSeparator)
+
+	if path1EndsWithSeparator && path2StartsWithSeparator {
+		return path1 + path2[1:]
+	} else if path1EndsWithSeparator || path2StartsWithSeparator {
+		return path1 + path2
+	} else {
+		return path1 + s.pathSeparator + path2
 	}
-	pattDirs := a.tokenizePattern(pattern)
-	if fullMatch && a.caseSensitive && !a.isPotentialMatch(path, pattDirs) {
-		return false
+}
+
+func (s *matcherState) extractPathWithinPattern(pattern string, patternParts []string, path string) string {
+	pathParts := pkg.TokenizeToStringArray(path, s.pathSeparator, s.trimTokens, true)
+	builder := bytes.NewBufferString("")
+	pathStarted := false
+	for segment := 0; segment < len(patternParts); segment++ {
+		patternPart := patternParts[segment]
+		if strings.IndexByte(patternPart, '*') > -1 || strings.IndexByte(patternPart, '?') > -1 {
+			for ; segment < len(pathParts); segment++ {
+				if pathStarted || segment == 0 && !strings.HasPrefix(pattern, s.pathSeparator) {
+					builder.WriteString(s.pathSeparator)
+				}
+				builder.WriteString(pathParts[segment])
+				pathStarted = true
+			}
+		}
+	}
+	return builder.String()
+}
+
+func (s *matcherState) doMatch(pattern, path string, fullMatch bool, uriTemplateVariables map[string]string) bool {
+	result, err := s.tryDoMatch(pattern, path, fullMatch, uriTemplateVariables)
+	if err != nil {
+		panic(err.Error())
+	}
+	return result
+}
+
+func (s *matcherState) tryDoMatch(pattern, path string, fullMatch bool, uriTemplateVariables map[string]string) (bool, error) {
+	//todo path is null
+	if strings.HasPrefix(path, s.pathSeparator) != strings.HasPrefix(pattern, s.pathSeparator) {
+		return false, nil
+	}
+	return s.matchTokenized(pattern, s.tokenizePattern(pattern), nil, path, fullMatch, uriTemplateVariables)
+}
+
+// matchTokenized runs the matching algorithm against an already tokenized pattern.
+// matchers holds precompiled segment matchers (see Pattern); when nil they are
+// looked up in the stringMatcherCache instead, at most once per segment.
+//
+// With P pattern segments and N path segments it performs O(P×N) segment
+// comparisons: the segments before the first and after the last "**" are
+// compared once each, and the ones in between are matched by
+// matchDoubleWildcards, which compares each (pattern segment, path segment)
+// pair at most once to decide the match.
+func (s *matcherState) matchTokenized(pattern string, pattDirs []string, matchers []*AntPathStringMatcher, path string, fullMatch bool, uriTemplateVariables map[string]string) (bool, error) {
+	if fullMatch && s.caseSensitive && !s.isPotentialMatch(path, pattDirs) {
+		return false, nil
+	}
+	if matchers == nil {
+		var matcherBuf [PATH_SEGMENTS_BUFFER]*AntPathStringMatcher
+		if len(pattDirs) <= len(matcherBuf) {
+			matchers = matcherBuf[:len(pattDirs)]
+		} else {
+			matchers = make([]*AntPathStringMatcher, len(pattDirs))
+		}
 	}
 
-	pathDirs := a.tokenizePath(path)
+	// the path segments are substrings of path kept in a stack buffer, so
+	// matching a cached pattern does not allocate
+	var buf [PATH_SEGMENTS_BUFFER]string
+	pathDirs := pkg.AppendTokens(buf[:0], path, s.pathSeparator, s.trimTokens, true)
 	pattIdxStart, pattIdxEnd, pathIdxStart, pathIdxEnd := 0, len(pattDirs)-1, 0, len(pathDirs)-1
 
 	for pattIdxStart <= pattIdxEnd && pathIdxStart <= pathIdxEnd {
@@ -193,8 +402,9 @@ func (a *AntPathMatcher) doMatch(pattern, path string, fullMatch bool, uriTempla
 		if "**" == pattDir {
 			break
 		}
-		if !a.matchStrings(pattDir, pathDirs[pathIdxStart], uriTemplateVariables) {
-			return false
+		matched, err := s.matchSegment(pattDirs, matchers, pattIdxStart, pathDirs[pathIdxStart], uriTemplateVariables)
+		if err != nil || !matched {
+			return false, err
 		}
 		pattIdxStart++
 		pathIdxStart++
@@ -203,26 +413,26 @@ func (a *AntPathMatcher) doMatch(pattern, path string, fullMatch bool, uriTempla
 	if pathIdxStart > pathIdxEnd {
 		// Path is exhausted, only match if rest of pattern is * or **'s
 		if pattIdxStart > pattIdxEnd {
-			return strings.HasSuffix(pattern, a.pathSeparator) == strings.HasSuffix(path, a.pathSeparator)
+			return strings.HasSuffix(pattern, s.pathSeparator) == strings.HasSuffix(path, s.pathSeparator), nil
 		}
 		if !fullMatch {
-			return true
+			return true, nil
 		}
-		if pattIdxStart == pattIdxEnd && pattDirs[pattIdxStart] == "*" && strings.HasSuffix(path, a.pathSeparator) {
-			return true
+		if pattIdxStart == pattIdxEnd && pattDirs[pattIdxStart] == "*" && strings.HasSuffix(path, s.pathSeparator) {
+			return true, nil
 		}
 		for i := pattIdxStart; i <= pattIdxEnd; i++ {
 			if pattDirs[i] != "**" {
-				return false
+				return false, nil
 			}
 		}
-		return true
+		return true, nil
 	} else if pattIdxStart > pattIdxEnd {
 		// String not exhausted, but pattern is. Failure.
-		return false
+		return false, nil
 	} else if !fullMatch && "**" == pattDirs[pattIdxStart] {
 		// Path start definitely matches due to "**" part in pattern.
-		return true
+		return true, nil
 	}
 
 	// up to last '**'
@@ -231,11 +441,12 @@ func (a *AntPathMatcher) doMatch(pattern, path string, fullMatch bool, uriTempla
 		if pattDir == "**" {
 			break
 		}
-		if !a.matchStrings(pattDir, pathDirs[pathIdxEnd], uriTemplateVariables) {
-			return false
+		matched, err := s.matchSegment(pattDirs, matchers, pattIdxEnd, pathDirs[pathIdxEnd], uriTemplateVariables)
+		if err != nil || !matched {
+			return false, err
 		}
-		if pattIdxEnd == len(pattDirs)-1 && strings.HasSuffix(pattern, a.pathSeparator) != strings.HasSuffix(path, a.pathSeparator) {
-			return false
+		if pattIdxEnd == len(pattDirs)-1 && strings.HasSuffix(pattern, s.pathSeparator) != strings.HasSuffix(path, s.pathSeparator) {
+			return false, nil
 		}
 		pattIdxEnd--
 		pathIdxEnd--
@@ -244,61 +455,110 @@ func (a *AntPathMatcher) doMatch(pattern, path string, fullMatch bool, uriTempla
 		// String is exhausted
 		for i := pattIdxStart; i <= pattIdxEnd; i++ {
 			if pattDirs[i] != "**" {
-				return false
+				return false, nil
 			}
 		}
-		return true
-	}
-	for pattIdxStart != pattIdxEnd && pathIdxStart <= pathIdxEnd {
-		patIdxTmp := -1
-		for i := pattIdxStart + 1; i <= pattIdxEnd; i++ {
-			if pattDirs[i] == "**" {
-				patIdxTmp = i
-				break
+		return true, nil
+	}
+	return s.matchDoubleWildcards(pattDirs, matchers, pattIdxStart, pattIdxEnd, pathDirs[pathIdxStart:pathIdxEnd+1], uriTemplateVariables)
+}
+
+// matchDoubleWildcards matches pathDirs against the pattern segments from the
+// "**" at pattIdxStart to the one at pattIdxEnd. It simulates them as an NFA
+// whose state k, for the pattern segment at pattIdxStart+k, is active once the
+// segments before it match the path segments read so far. A "**" state stays
+// active on every path segment and lets the next state through; any other
+// state hands over to the next one when its segment matches. Each path segment
+// is read once and compared with the segment of each active state once, so it
+// takes at most P×N comparisons, and the first time the last "**" is reached
+// the rest of the path matches too.
+//
+// The variables are extracted afterwards with at most P more comparisons: each
+// run of segments between two "**" is placed where it first completed, its
+// leftmost occurrence after the run before it.
+func (s *matcherState) matchDoubleWildcards(pattDirs []string, matchers []*AntPathStringMatcher, pattIdxStart, pattIdxEnd int, pathDirs []string, uriTemplateVariables map[string]string) (bool, error) {
+	n := pattIdxEnd - pattIdxStart + 1
+	var activeBuf, nextBuf [PATH_SEGMENTS_BUFFER]bool
+	var reachedBuf [PATH_SEGMENTS_BUFFER]int
+	active, next, reached := activeBuf[:0], nextBuf[:0], reachedBuf[:0]
+	if n <= PATH_SEGMENTS_BUFFER {
+		active, next, reached = activeBuf[:n], nextBuf[:n], reachedBuf[:n]
+	} else {
+		active, next, reached = make([]bool, n), make([]bool, n), make([]int, n)
+	}
+	for k := range reached {
+		reached[k] = -1
+	}
+	active[0] = true
+	for i := 0; ; i++ {
+		// follow the "**" that match no segment, remembering when each
+		// state is first reached
+		for k := range active {
+			if !active[k] {
+				continue
 			}
-		}
-		if patIdxTmp == pattIdxStart+1 {
-			// '**/**' situation, so skip one
-			pattIdxStart++
-			continue
-		}
-		// Find the pattern between padIdxStart & padIdxTmp in str between
-		// strIdxStart & strIdxEnd
-		patLength, strLength, foundIdx := patIdxTmp-pattIdxStart-1, pathIdxEnd-pathIdxStart+1, -1
-	strLoop:
-		for i := 0; i < strLength-patLength; i++ {
-			for j := 0; j < patLength; j++ {
-				subPat, subStr := pattDirs[pattIdxStart+j+1], pathDirs[pathIdxStart+i+j]
-				if !a.matchStrings(subPat, subStr, uriTemplateVariables) {
-					continue strLoop
-				}
+			if reached[k] == -1 {
+				reached[k] = i
+			}
+			if k+1 < n && pattDirs[pattIdxStart+k] == "**" {
+				active[k+1] = true
 			}
-			foundIdx = pathIdxStart + i
+		}
+		if active[n-1] {
 			break
 		}
-		if foundIdx == -1 {
-			return false
+		if i == len(pathDirs) {
+			return false, nil
+		}
+		for k := range next {
+			next[k] = false
 		}
-		pattIdxStart = patIdxTmp
-		pathIdxStart = foundIdx + patLength
+		for k := range active {
+			if !active[k] {
+				continue
+			}
+			if pattDirs[pattIdxStart+k] == "**" {
+				next[k] = true
+				continue
+			}
+			matched, err := s.matchSegment(pattDirs, matchers, pattIdxStart+k, pathDirs[i], nil)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				next[k+1] = true
+			}
+		}
+		active, next = next, active
 	}
-	for i := pattIdxStart; i <= pattIdxEnd; i++ {
-		if pattDirs[i] != "**" {
-			return false
+	if uriTemplateVariables == nil {
+		return true, nil
+	}
+	for prev, k := 0, 1; k < n; k++ {
+		if pattDirs[pattIdxStart+k] != "**" {
+			continue
 		}
+		// the run between the "**" at prev and k ends where k was reached
+		runStart := reached[k] - (k - prev - 1)
+		for j := prev + 1; j < k; j++ {
+			if _, err := s.matchSegment(pattDirs, matchers, pattIdxStart+j, pathDirs[runStart+j-prev-1], uriTemplateVariables); err != nil {
+				return false, err
+			}
+		}
+		prev = k
 	}
-
-	return true
+	return true, nil
 }
-func (a *AntPathMatcher) isPotentialMatch(path string, pattDirs []string) bool {
-	if !a.trimTokens {
+
+func (s *matcherState) isPotentialMatch(path string, pattDirs []string) bool {
+	if !s.trimTokens {
 		pos := 0
 		for k := range pattDirs {
-			skipped := skipSeparator(pos, path, a.pathSeparator)
+			skipped := skipSeparator(pos, path, s.pathSeparator)
 			pos += skipped
-			skipped = skipSegment(path, pos, []byte(pattDirs[k]))
+			skipped = skipSegment(path, pos, pattDirs[k])
 			if skipped < len(pattDirs[k]) {
-				return skipped > 0 || (len(pattDirs[k]) > 0 && isWildcardChar([]byte(pattDirs[k])[0]))
+				return skipped > 0 || (len(pattDirs[k]) > 0 && isWildcardChar(pattDirs[k][0]))
 			}
 			pos += skipped
 		}
@@ -306,7 +566,7 @@ func (a *AntPathMatcher) isPotentialMatch(path string, pattDirs []string) bool {
 	return true
 }
 
-func skipSegment(path string, pos int, prefix []byte) int {
+func skipSegment(path string, pos int, prefix string) int {
 	skipped := 0
 	for i := 0; i < len(prefix); i++ {
 		if isWildcardChar(prefix[i]) {
@@ -316,7 +576,7 @@ func skipSegment(path string, pos int, prefix []byte) int {
 		if currPos >= len(path) {
 			return 0
 		}
-		if prefix[i] == []byte(path)[currPos] {
+		if prefix[i] == path[currPos] {
 			skipped++
 		}
 	}
@@ -340,76 +600,83 @@ func isWildcardChar(c byte) bool {
 	return false
 }
 
-func (a *AntPathMatcher) tokenizePattern(pattern string) []string {
-	tokenized := make([]string, 0)
-	cachePatterns := a.cachePatterns
+func (s *matcherState) tokenizePattern(pattern string) []string {
+	var tokenized []string
+	cachePatterns := s.cachePatterns
 	if !cachePatterns.Valid || cachePatterns.Bool {
-		tmp, ok := a.tokenizedPatternCache.Load(pattern)
+		tmp, ok := s.tokenizedPatternCache.Load(pattern)
 		if ok {
 			tokenized, _ = tmp.([]string)
+			atomic.AddUint64(&s.counters.tokenizedPatternHits, 1)
+		} else {
+			atomic.AddUint64(&s.counters.tokenizedPatternMisses, 1)
 		}
 	}
 	if tokenized == nil || len(tokenized) == 0 {
-		tokenized = a.tokenizePath(pattern)
-		if !a.cachePatterns.Valid && a.tokenizedPatternCache.Len() > CACHE_TURNOFF_THRESHOLD {
+		tokenized = s.tokenizePath(pattern)
+		if s.adaptiveCache() && s.tokenizedPatternCache.Len() > cacheTurnoffThreshold {
 			// Try to adapt to the runtime situation that we're encountering:
 			// There are obviously too many different patterns coming in here...
 			// So let's turn off the cache since the patterns are unlikely to be reoccurring.
-			a.DeactivatePatternCache()
+			s.deactivatePatternCache()
 			return tokenized
 		}
-		if !a.cachePatterns.Valid || cachePatterns.Bool {
-			a.tokenizedPatternCache.Store(pattern, tokenized)
+		if !s.cachePatterns.Valid || cachePatterns.Bool {
+			actual, _ := s.tokenizedPatternCache.LoadOrStore(pattern, tokenized)
+			tokenized = actual.([]string)
 		}
 	}
 	return tokenized
 }
 
-func (a *AntPathMatcher) tokenizePath(path string) []string {
-	return pkg.TokenizeToStringArray(path, a.pathSeparator, a.trimTokens, true)
+func (s *matcherState) tokenizePath(path string) []string {
+	return pkg.TokenizeToStringArray(path, s.pathSeparator, s.trimTokens, true)
 }
 
-func (a *AntPathMatcher) matchStrings(pattern, str string, uriTemplateVariables map[string]string) bool {
-	return a.getStringMatcher(pattern).matchStrings(str, uriTemplateVariables)
+// matchSegment matches str against pattern segment idx, resolving and
+// remembering its matcher in matchers on first use.
+func (s *matcherState) matchSegment(pattDirs []string, matchers []*AntPathStringMatcher, idx int, str string, uriTemplateVariables map[string]string) (bool, error) {
+	if matchers[idx] == nil {
+		matcher, err := s.getStringMatcher(pattDirs[idx])
+		if err != nil {
+			return false, err
+		}
+		matchers[idx] = matcher
+	}
+	return matchers[idx].tryMatchStrings(str, uriTemplateVariables)
 }
 
-func (a *AntPathMatcher) getStringMatcher(pattern string) *AntPathStringMatcher {
+func (s *matcherState) getStringMatcher(pattern string) (*AntPathStringMatcher, error) {
 	var matcher *AntPathStringMatcher
-	cachePatterns := a.cachePatterns
+	cachePatterns := s.cachePatterns
 	if !cachePatterns.Valid || cachePatterns.Bool {
-		tmp, ok := a.stringMatcherCache.Load(pattern)
+		tmp, ok := s.stringMatcherCache.Load(pattern)
 		if ok {
 			matcher = tmp.(*AntPathStringMatcher)
+			atomic.AddUint64(&s.counters.stringMatcherHits, 1)
+		} else {
+			atomic.AddUint64(&s.counters.stringMatcherMisses, 1)
 		}
 	}
 	if matcher == nil {
-		matcher = NewAntPathStringMatcherWithCaseSensitive(pattern, a.caseSensitive)
-		if !cachePatterns.Valid && a.stringMatcherCache.Len() >= CACHE_TURNOFF_THRESHOLD {
+		var err error
+		matcher, err = newAntPathStringMatcher(pattern, s.caseSensitive, s.defaultVariablePattern)
+		if err != nil {
+			return nil, err
+		}
+		if s.adaptiveCache() && s.stringMatcherCache.Len() >= cacheTurnoffThreshold {
 			// Try to adapt to the runtime situation that we're encountering:
 			// There are obviously too many different patterns coming in here...
 			// So let's turn off the cache since the patterns are unlikely to be reoccurring.
-			a.DeactivatePatternCache()
-			return matcher
+			s.deactivatePatternCache()
+			return matcher, nil
 		}
-		if (!a.cachePatterns.Valid) || cachePatterns.Bool {
-			a.stringMatcherCache.Store(pattern, matcher)
+		if (!s.cachePatterns.Valid) || cachePatterns.Bool {
+			actual, _ := s.stringMatcherCache.LoadOrStore(pattern, matcher)
+			matcher = actual.(*AntPathStringMatcher)
 		}
 	}
-	return matcher
-}
-
-func (a *AntPathMatcher) DeactivatePatternCache() {
-	a.cachePatterns.SetValid(false)
-	a.tokenizedPatternCache = pkg.MySyncMap{}
-	a.stringMatcherCache = pkg.MySyncMap{}
-}
-
-func (a *AntPathMatcher) SetPathSeparator(pathSeparator string) {
-	if strings.TrimSpace(pathSeparator) == "" {
-		pathSeparator = DEFAULT_PATH_SEPARATOR
-	}
-	a.pathSeparator = pathSeparator
-	a.pathSeparatorPatternCache = NewPathSeparatorPatternCache(pathSeparator)
+	return matcher, nil
 }
 
 //endregion
@@ -565,17 +832,28 @@ type AntPathStringMatcher struct {
 	rawPattern    string
 	variableNames []string
 	pattern       *regexp.Regexp
+	glob          globKind
+	literal       string
 }
 
 // const DEFAULT_VARIABLE_PATTERN="(.*)"
 const DEFAULT_VARIABLE_PATTERN = "((?s).*)"
 
+var GLOB_PATTERN = regexp.MustCompile("\\?|\\*|\\{((?:\\{[^/]+?\\}|[^/{}]|\\\\[{}])+?)\\}")
+
 func NewAntPathStringMatcher(pattern string) *AntPathStringMatcher {
 	return NewAntPathStringMatcherWithCaseSensitive(pattern, true)
 }
 
 func NewAntPathStringMatcherWithCaseSensitive(pattern string, caseSensitive bool) *AntPathStringMatcher {
-	GLOB_PATTERN := regexp.MustCompile("\\?|\\*|\\{((?:\\{[^/]+?\\}|[^/{}]|\\\\[{}])+?)\\}")
+	a, err := newAntPathStringMatcher(pattern, caseSensitive, DEFAULT_VARIABLE_PATTERN)
+	if err != nil {
+		panic(err.Error())
+	}
+	return a
+}
+
+func newAntPathStringMatcher(pattern string, caseSensitive bool, defaultVariablePattern string) (*AntPathStringMatcher, error) {
 	a := &AntPathStringMatcher{
 		caseSensitive: caseSensitive,
 		rawPattern:    pattern,
@@ -593,7 +871,7 @@ func NewAntPathStringMatcherWithCaseSensitive(pattern string, caseSensitive bool
 		} else if strings.HasPrefix(allStrs[k], "{") && strings.HasSuffix(allStrs[k], "}") {
 			colonIdx := strings.IndexRune(allStrs[k], ':')
 			if colonIdx == -1 {
-				patternBuilder.WriteString(DEFAULT_VARIABLE_PATTERN)
+				patternBuilder.WriteString(defaultVariablePattern)
 				a.variableNames = append(a.variableNames, GLOB_PATTERN.FindStringSubmatch(allStrs[k])[1])
 			} else {
 				variablePattern := allStrs[k][colonIdx+1 : len(allStrs[k])-1]
@@ -609,6 +887,9 @@ func NewAntPathStringMatcherWithCaseSensitive(pattern string, caseSensitive bool
 	if len(allStrs) == 0 {
 		a.exactMatch = true
 		a.pattern = nil
+	} else if a.initGlob(allStrs, defaultVariablePattern) {
+		// literals, '*' and '?' only, or a lone "{name}": no regexp needed
+		a.exactMatch = false
 	} else {
 		a.exactMatch = false
 		patternBuilder.WriteString(quote(pattern, end, len(pattern)))
@@ -616,9 +897,14 @@ func NewAntPathStringMatcherWithCaseSensitive(pattern string, caseSensitive bool
 		if !a.caseSensitive {
 			str = "(?i)"
 		}
-		a.pattern = regexp.MustCompile(str + patternBuilder.String())
+		// anchored so that a plain match needs no submatches, which would allocate
+		compiled, err := regexp.Compile("^(?:" + str + patternBuilder.String() + ")$")
+		if err != nil {
+			return nil, newError(ErrInvalidPattern, err.Error())
+		}
+		a.pattern = compiled
 	}
-	return a
+	return a, nil
 }
 
 func quote(s string, start, end int) string {
@@ -629,39 +915,48 @@ func quote(s string, start, end int) string {
 }
 
 func (a *AntPathStringMatcher) matchStrings(str string, uriTemplateVariables map[string]string) bool {
+	result, err := a.tryMatchStrings(str, uriTemplateVariables)
+	if err != nil {
+		panic(err.Error())
+	}
+	return result
+}
+
+func (a *AntPathStringMatcher) tryMatchStrings(str string, uriTemplateVariables map[string]string) (bool, error) {
 	if a.exactMatch {
 		if a.caseSensitive {
-			return a.rawPattern == str
+			return a.rawPattern == str, nil
 		} else {
-			return strings.EqualFold(a.rawPattern, str)
+			return strings.EqualFold(a.rawPattern, str), nil
 		}
+	} else if a.glob != globNone {
+		return a.matchGlob(str, uriTemplateVariables), nil
 	} else if a.pattern != nil {
+		if uriTemplateVariables == nil {
+			return a.pattern.MatchString(str), nil
+		}
 		strs := a.pattern.FindStringSubmatch(str)
 		if strs == nil || len(strs) == 0 {
-			return false
+			return false, nil
 		}
 		count := len(strs) - 1
-		if strs[0] == str {
-			if uriTemplateVariables != nil {
-				if len(a.variableNames) != count {
-					panic(fmt.Sprintf("The number of capturing groups in the pattern segment " +
-						a.pattern.String() + " does not match the number of URI template variables it defines, " +
-						"which can occur if capturing groups are used in a URI template regex. " +
-						"Use non-capturing groups instead."))
-				}
-				for i := 1; i <= count; i++ {
-					name := a.variableNames[i-1]
-					if strings.HasPrefix(name, "*") {
-						panic("Capturing patterns (" + name + ") are not " +
-							"supported by the AntPathMatcher. Use the PathPatternParser instead.")
-					}
-					uriTemplateVariables[name] = strs[i]
-				}
+		if len(a.variableNames) != count {
+			return false, newError(ErrCapturingGroup, "The number of capturing groups in the pattern segment "+
+				a.pattern.String()+" does not match the number of URI template variables it defines, "+
+				"which can occur if capturing groups are used in a URI template regex. "+
+				"Use non-capturing groups instead.")
+		}
+		for i := 1; i <= count; i++ {
+			name := a.variableNames[i-1]
+			if strings.HasPrefix(name, "*") {
+				return false, newError(ErrCapturingPattern, "Capturing patterns ("+name+") are not "+
+					"supported by the AntPathMatcher. Use the PathPatternParser instead.")
 			}
-			return true
+			uriTemplateVariables[name] = strs[i]
 		}
+		return true, nil
 	}
-	return false
+	return false, nil
 }
 
 //endregion
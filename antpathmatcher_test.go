@@ -1,7 +1,11 @@
 package antpathmatcher
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -110,7 +114,7 @@ func Test_match(t *testing.T) {
 // SPR-14247
 func Test_matchWithTrimTokensEnabled(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
-	pathMatcher.trimTokens = true
+	pathMatcher.SetTrimTokens(true)
 	e := assert.New(t)
 	e.True(pathMatcher.MatchStart("/foo/bar", "/foo /bar"))
 }
@@ -373,6 +377,46 @@ func Test_extractUriTemplateVarsRegexCapturingGroups(t *testing.T) {
 	pathMatcher.ExtractUriTemplateVariables("/web/{id:foo(bar)?}", "/web/foobar")
 }
 
+func Test_tryExtractUriTemplateVariables(t *testing.T) {
+	var safePathMatcher SafePathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	result, err := safePathMatcher.TryExtractUriTemplateVariables("/hotels/{hotel}", "/hotels/1")
+	e.Nil(err)
+	e.Equal(result["hotel"], "1")
+
+	result, err = safePathMatcher.TryExtractUriTemplateVariables("/hotels/{hotel}", "/motels/1")
+	e.Nil(result)
+	e.True(errors.Is(err, ErrNoMatch))
+
+	// SPR-8455
+	_, err = safePathMatcher.TryExtractUriTemplateVariables("/web/{id:foo(bar)?}", "/web/foobar")
+	e.True(errors.Is(err, ErrCapturingGroup))
+	e.Contains(err.Error(), "The number of capturing groups in the pattern")
+
+	_, err = safePathMatcher.TryExtractUriTemplateVariables("/files/{*path}", "/files/a")
+	e.True(errors.Is(err, ErrCapturingPattern))
+
+	_, err = safePathMatcher.TryExtractUriTemplateVariables("/users/{id:[0-9}", "/users/1")
+	e.True(errors.Is(err, ErrInvalidPattern))
+}
+
+func Test_tryMatch(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	result, err := pathMatcher.TryMatch("/hotels/*", "/hotels/1")
+	e.Nil(err)
+	e.True(result)
+
+	result, err = pathMatcher.TryMatchStart("/hotels/*/bookings", "/hotels/1")
+	e.Nil(err)
+	e.True(result)
+
+	result, err = pathMatcher.TryMatch("/users/{id:[0-9}", "/users/1")
+	e.False(result)
+	e.True(errors.Is(err, ErrInvalidPattern))
+	e.Panics(func() { pathMatcher.Match("/users/{id:[0-9}", "/users/1") })
+}
+
 func Test_combine(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
 	e := assert.New(t)
@@ -413,6 +457,14 @@ func Test_combineWithTwoFileExtensionPatterns(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
 	e := assert.New(t)
 	e.Panics(func() { pathMatcher.Combine("/*.html", "/*.txt") })
+
+	_, err := pathMatcher.TryCombine("/*.html", "/*.txt")
+	e.True(errors.Is(err, ErrIncompatibleExtensions))
+	e.Equal(err.Error(), "Cannot combine patterns: /*.html vs /*.txt")
+
+	combined, err := pathMatcher.TryCombine("/*.html", "/hotel")
+	e.Nil(err)
+	e.Equal(combined, "/hotel.html")
 }
 
 func Test_patternComparator(t *testing.T) {
@@ -467,16 +519,82 @@ func Test_patternComparator(t *testing.T) {
 
 func Test_patternComparatorSort(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
-	comparator := pathMatcher.GetPatternComparator("/hotels/new")
 	e := assert.New(t)
-	_, _ = comparator, e
-	//todo whole case sort.(Compara<string>) i have no idea how to implementation
+	sorted := func(path string, patterns ...string) []string {
+		pathMatcher.SortPatterns(path, patterns)
+		return patterns
+	}
+	shuffled := func(path string, patterns ...string) []string {
+		rand.Shuffle(len(patterns), func(i, j int) {
+			patterns[i], patterns[j] = patterns[j], patterns[i]
+		})
+		return sorted(path, patterns...)
+	}
+	// the null pattern cases of Spring do not apply to Go strings
+
+	e.Equal(sorted("/hotels/new", "/hotels/*", "/hotels/new"), []string{"/hotels/new", "/hotels/*"})
+	e.Equal(sorted("/hotels/new", "/hotels/new", "/hotels/*"), []string{"/hotels/new", "/hotels/*"})
+
+	e.Equal(sorted("/hotels/new", "/hotels/**", "/hotels/*"), []string{"/hotels/*", "/hotels/**"})
+	e.Equal(sorted("/hotels/new", "/hotels/*", "/hotels/**"), []string{"/hotels/*", "/hotels/**"})
+
+	e.Equal(sorted("/hotels/new", "/hotels/{hotel}", "/hotels/new"), []string{"/hotels/new", "/hotels/{hotel}"})
+	e.Equal(sorted("/hotels/new", "/hotels/new", "/hotels/{hotel}"), []string{"/hotels/new", "/hotels/{hotel}"})
+
+	e.Equal(sorted("/hotels/new", "/hotels/*", "/hotels/{hotel}", "/hotels/new"),
+		[]string{"/hotels/new", "/hotels/{hotel}", "/hotels/*"})
+
+	e.Equal(shuffled("/hotels/new", "/hotels/ne*", "/hotels/n*"), []string{"/hotels/ne*", "/hotels/n*"})
+
+	e.Equal(shuffled("/hotels/new.html", "/hotels/new.*", "/hotels/{hotel}"),
+		[]string{"/hotels/new.*", "/hotels/{hotel}"})
+
+	e.Equal(sorted("/web/endUser/action/login.html", "/**/login.*", "/**/endUser/action/login.*"),
+		[]string{"/**/endUser/action/login.*", "/**/login.*"})
+}
+
+func Test_sortPatternsIsStable(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	patterns := []string{"/**", "/hotels/{a}", "/hotels/{b}", "/hotels/new", "/hotels/{c}"}
+	pathMatcher.SortPatterns("/hotels/new", patterns)
+	e.Equal(patterns, []string{"/hotels/new", "/hotels/{a}", "/hotels/{b}", "/hotels/{c}", "/**"})
+}
+
+func Test_bestMatch(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	patterns := []string{"/**", "/hotels/*", "/hotels/{hotel}/**", "/hotels/{hotel}", "/hotels/{hotel}/bookings/{booking}"}
+
+	pattern, variables, ok := pathMatcher.BestMatch("/hotels/1", patterns)
+	e.True(ok)
+	e.Equal(pattern, "/hotels/{hotel}")
+	e.Equal(variables, map[string]string{"hotel": "1"})
+
+	pattern, variables, ok = pathMatcher.BestMatch("/hotels/1/bookings/2", patterns)
+	e.True(ok)
+	e.Equal(pattern, "/hotels/{hotel}/bookings/{booking}")
+	e.Equal(variables, map[string]string{"hotel": "1", "booking": "2"})
+
+	pattern, variables, ok = pathMatcher.BestMatch("/motels/1", patterns)
+	e.True(ok)
+	e.Equal(pattern, "/**")
+	e.Equal(variables, map[string]string{})
+
+	pattern, variables, ok = pathMatcher.BestMatch("motels", patterns)
+	e.False(ok)
+	e.Equal(pattern, "")
+	e.Nil(variables)
+
+	// equally specific patterns: the first one wins
+	pattern, _, _ = pathMatcher.BestMatch("/hotels/1", []string{"/hotels/{a}", "/hotels/{b}"})
+	e.Equal(pattern, "/hotels/{a}")
 }
 
 // SPR-8687
 func Test_trimTokensOff(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
-	pathMatcher.trimTokens = false
+	pathMatcher.SetTrimTokens(false)
 	e := assert.New(t)
 	e.True(pathMatcher.Match("/group/{groupName}/members", "/group/sales/members"))
 	e.True(pathMatcher.Match("/group/{groupName}/members", "/group/  sales/members"))
@@ -486,13 +604,54 @@ func Test_trimTokensOff(t *testing.T) {
 // SPR-13286
 func Test_caseInsensitive(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
-	pathMatcher.caseSensitive = false
+	pathMatcher.SetCaseSensitive(false)
 	e := assert.New(t)
 	e.True(pathMatcher.Match("/group/{groupName}/members", "/group/sales/members"))
 	e.True(pathMatcher.Match("/group/{groupName}/members", "/Group/Sales/Members"))
 	e.True(pathMatcher.Match("/Group/{groupName}/Members", "/group/Sales/members"))
 }
 
+func Test_newAntPathMatcherWithOptions(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher = NewAntPathMatcherWithOptions(WithCaseSensitive(false), WithTrimTokens(true))
+	e.True(pathMatcher.Match("/group/{groupName}/members", "/Group/ Sales /Members"))
+
+	pathMatcher = NewAntPathMatcherWithOptions(WithPathSeparator("."))
+	e.True(pathMatcher.Match(".bla.**.bla", ".bla.testing.testing.bla"))
+
+	pathMatcher = NewAntPathMatcherWithOptions(WithDefaultVariablePattern("[^.]+"))
+	e.False(pathMatcher.Match("/{name}", "/test.html"))
+	e.Equal(pathMatcher.ExtractUriTemplateVariables("/{name}.{extension}", "/test.html")["name"], "test")
+
+	pathMatcher = NewAntPathMatcherWithOptions(WithCachePatterns(false))
+	e.True(pathMatcher.Match("/hotels/*", "/hotels/1"))
+	e.Equal(pathMatcher.load().stringMatcherCache.Len(), 0)
+}
+
+func Test_settersInvalidatePatternCache(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	e.False(pathMatcher.Match("/group/{groupName}/members", "/Group/Sales/Members"))
+	pathMatcher.SetCaseSensitive(false)
+	e.True(pathMatcher.Match("/group/{groupName}/members", "/Group/Sales/Members"))
+	pathMatcher.SetCaseSensitive(true)
+	e.False(pathMatcher.Match("/group/{groupName}/members", "/Group/Sales/Members"))
+
+	e.False(pathMatcher.Match("/foo/bar", "/foo /bar"))
+	pathMatcher.SetTrimTokens(true)
+	e.True(pathMatcher.Match("/foo/bar", "/foo /bar"))
+
+	e.True(pathMatcher.Match("a.*", "a.b"))
+	pathMatcher.SetPathSeparator(".")
+	e.True(pathMatcher.Match("a.*", "a.b"))
+	e.False(pathMatcher.Match("*", "a.b"))
+
+	pathMatcher = NewAntPathMatcher()
+	e.True(pathMatcher.Match("/{name}", "/test.html"))
+	pathMatcher.SetDefaultVariablePattern("[^.]+")
+	e.False(pathMatcher.Match("/{name}", "/test.html"))
+}
+
 // gh-27506
 func Test_consistentMatchWithWildcardsAndTrailingSlash(t *testing.T) {
 	pathMatcher = NewAntPathMatcher()
@@ -503,3 +662,186 @@ func Test_consistentMatchWithWildcardsAndTrailingSlash(t *testing.T) {
 	e.False(pathMatcher.Match("/**/foo", "/en/foo/"))
 
 }
+
+// a run of segments between two "**" may end on the last path segment
+func Test_matchRunAtPathEnd(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	e.True(pathMatcher.Match("/**/a/**", "/a"))
+	e.True(pathMatcher.Match("/**/a/b/**", "/x/a/b"))
+	e.True(pathMatcher.Match("/x/**/a/**/y", "/x/a/y"))
+	e.False(pathMatcher.Match("/**/a/b/**", "/x/a"))
+}
+
+func Test_concurrentReconfiguration(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	wg := sync.WaitGroup{}
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if !pathMatcher.Match("/hotels/{hotel}/**", "/hotels/1/bookings/2") {
+					t.Error("expected /hotels/{hotel}/** to match")
+					return
+				}
+				if result := pathMatcher.ExtractUriTemplateVariables("/hotels/{hotel}", "/hotels/1"); result["hotel"] != "1" {
+					t.Error("unexpected variables", result)
+					return
+				}
+				pathMatcher.MatchStart("/hotels/*/bookings", "/hotels/1")
+				pathMatcher.Combine("/hotels/*", "/booking")
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		pathMatcher.SetCaseSensitive(i%2 == 0)
+		pathMatcher.SetTrimTokens(i%3 == 0)
+		pathMatcher.SetCachePatterns(i%5 != 0)
+		pathMatcher.DeactivatePatternCache()
+		pathMatcher.SetPathSeparator(DEFAULT_PATH_SEPARATOR)
+	}
+	close(done)
+	wg.Wait()
+}
+
+func Test_concurrentPathSeparatorChange(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				// "*" matches "a.b" with "/" as separator but not with "."
+				pathMatcher.Match("*", "a.b")
+				pathMatcher.ExtractPathWithinPattern("a.*", "a.b.c")
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			pathMatcher.SetPathSeparator(".")
+		} else {
+			pathMatcher.SetPathSeparator("/")
+		}
+	}
+	wg.Wait()
+	e.True(pathMatcher.Match("*", "a.b"))
+}
+
+func Test_zeroValueAntPathMatcher(t *testing.T) {
+	e := assert.New(t)
+	var zero AntPathMatcher
+	e.True(zero.Match("/hotels/*", "/hotels/1"))
+	zero.SetCaseSensitive(false)
+	e.True(zero.Match("/hotels/*", "/HOTELS/1"))
+}
+
+func Test_matchAllocations(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	cases := [][2]string{
+		{"/docs/commit.html", "/docs/commit.html"},
+		{"/docs/*.html", "/docs/commit.html"},
+		{"/hotels/{hotel}", "/hotels/1"},
+		{"/hotels/{hotel:\\d+}", "/hotels/1"},
+		{"/x/{a}-{b}", "/x/1-2"},
+		{"/docs/**/*.html", "/docs/cvs/other/commit.html"},
+		{"/docs/**/*.html", "/docs/cvs/other/commit.txt"},
+	}
+	for _, c := range cases {
+		pattern, path := c[0], c[1]
+		pathMatcher.Match(pattern, path)
+		e.Equal(testing.AllocsPerRun(100, func() { pathMatcher.Match(pattern, path) }), 0.0, pattern)
+		compiled := MustCompile(pattern)
+		e.Equal(testing.AllocsPerRun(100, func() { compiled.Match(path) }), 0.0, pattern)
+	}
+}
+
+func Test_matchDeepPath(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	path := strings.Repeat("/a", PATH_SEGMENTS_BUFFER*2) + "/b.html"
+	e.True(pathMatcher.Match("/a/**/*.html", path))
+	e.True(pathMatcher.Match(strings.Repeat("/*", PATH_SEGMENTS_BUFFER*2)+"/b.html", path))
+	e.False(pathMatcher.Match(strings.Repeat("/*", PATH_SEGMENTS_BUFFER*2), path))
+	e.True(pathMatcher.Match(strings.Repeat("/**/a", PATH_SEGMENTS_BUFFER)+"/**/b.html", path))
+	e.False(pathMatcher.Match(strings.Repeat("/**/a", PATH_SEGMENTS_BUFFER*2+1)+"/**", path))
+}
+
+// the runs between two "**" are placed at their leftmost occurrence
+func Test_extractUriTemplateVariablesBetweenDoubleWildcards(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	result := pathMatcher.ExtractUriTemplateVariables("/**/{x}/{y}/z/**/{w}/q/**", "/y/a/b/z/c/a/q/z/d/q")
+	e.Equal(result, map[string]string{"x": "a", "y": "b", "w": "a"})
+	result = pathMatcher.ExtractUriTemplateVariables("/v/**/{x}/**/{y}", "/v/a/b/c")
+	e.Equal(result, map[string]string{"x": "a", "y": "c"})
+}
+
+func Test_regexSegmentFullMatch(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	// the segment must match as a whole, not just its leftmost alternative
+	e.True(pathMatcher.Match("/{x:a|ab}", "/ab"))
+	e.Equal(pathMatcher.ExtractUriTemplateVariables("/{x:a|ab}", "/ab"), map[string]string{"x": "ab"})
+	e.False(pathMatcher.Match("/{x:a|ab}", "/abc"))
+}
+
+func Test_matchDoubleWildcardRuns(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	e.True(pathMatcher.Match("/**/a/**", "/a"))
+	e.True(pathMatcher.Match("/x/**/a/b/**/y", "/x/a/b/y"))
+	e.True(pathMatcher.Match("/**/a/b/**", "/a/b"))
+	e.False(pathMatcher.Match("/**/a/b/**", "/a"))
+	e.True(pathMatcher.Match("/**/a/**/b/**/c/**", "/a/b/c"))
+	e.False(pathMatcher.Match("/**/a/**/b/**/c/**", "/a/c/b"))
+}
+
+// Test_matchDoubleWildcardsReference checks every pattern of up to five
+// segments out of "a", "b", "*" and "**" against every path of up to five
+// segments out of "a" and "b", using a plain backtracking matcher as reference.
+func Test_matchDoubleWildcardsReference(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	var reference func(pattDirs, pathDirs []string) bool
+	reference = func(pattDirs, pathDirs []string) bool {
+		if len(pattDirs) == 0 {
+			return len(pathDirs) == 0
+		}
+		if pattDirs[0] == "**" {
+			return reference(pattDirs[1:], pathDirs) || len(pathDirs) > 0 && reference(pattDirs, pathDirs[1:])
+		}
+		return len(pathDirs) > 0 && (pattDirs[0] == "*" || pattDirs[0] == pathDirs[0]) &&
+			reference(pattDirs[1:], pathDirs[1:])
+	}
+	combinations := func(alphabet []string, maxLength int) [][]string {
+		result := [][]string{{}}
+		for k := 0; k < len(result); k++ {
+			if len(result[k]) < maxLength {
+				for _, segment := range alphabet {
+					result = append(result, append(append([]string{}, result[k]...), segment))
+				}
+			}
+		}
+		return result
+	}
+	patterns := combinations([]string{"a", "b", "*", "**"}, 5)
+	paths := combinations([]string{"a", "b"}, 5)
+	for _, pattDirs := range patterns[1:] {
+		pattern := "/" + strings.Join(pattDirs, "/")
+		for _, pathDirs := range paths[1:] {
+			path := "/" + strings.Join(pathDirs, "/")
+			e.Equal(pathMatcher.Match(pattern, path), reference(pattDirs, pathDirs), "%s %s", pattern, path)
+		}
+	}
+}
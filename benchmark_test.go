@@ -0,0 +1,196 @@
+package antpathmatcher
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// @Author :George
+// @File: benchmark_test
+// @Version: 1.0.0
+// @Date 2026/10/18 14:05
+
+// Cache misses must cost the same whether the cache is empty or close to
+// CACHE_TURNOFF_THRESHOLD.
+func Benchmark_tokenizePatternMiss(b *testing.B) {
+	for _, size := range []int{0, 1024, 16384, CACHE_TURNOFF_THRESHOLD - 1} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			s := NewAntPathMatcher().load()
+			for i := 0; i < size; i++ {
+				s.tokenizePattern("/cached/" + strconv.Itoa(i) + "/**")
+			}
+			patterns := make([]string, 1024)
+			for k := range patterns {
+				patterns[k] = "/missed/" + strconv.Itoa(k) + "/*.html"
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pattern := patterns[i%len(patterns)]
+				s.tokenizePattern(pattern)
+				s.tokenizedPatternCache.(*syncMapPatternCache).m.Delete(pattern)
+			}
+		})
+	}
+}
+
+func Benchmark_getStringMatcherMiss(b *testing.B) {
+	for _, size := range []int{0, 1024, 16384, CACHE_TURNOFF_THRESHOLD - 1} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			s := NewAntPathMatcher().load()
+			for i := 0; i < size; i++ {
+				_, _ = s.getStringMatcher("cached" + strconv.Itoa(i))
+			}
+			patterns := make([]string, 1024)
+			for k := range patterns {
+				patterns[k] = "missed" + strconv.Itoa(k)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pattern := patterns[i%len(patterns)]
+				_, _ = s.getStringMatcher(pattern)
+				s.stringMatcherCache.(*syncMapPatternCache).m.Delete(pattern)
+			}
+		})
+	}
+}
+
+var matchBenchmarks = []struct {
+	name    string
+	pattern string
+	path    string
+}{
+	{"literal", "/docs/commit.html", "/docs/commit.html"},
+	{"suffix", "/docs/*.html", "/docs/commit.html"},
+	{"prefix", "/docs/commit*", "/docs/commit.html"},
+	{"infix", "/docs/*mmi*", "/docs/commit.html"},
+	{"question", "/d?cs/c?mmit.html", "/docs/commit.html"},
+	{"generic", "/docs/c*t.h*l", "/docs/commit.html"},
+	{"variable", "/hotels/{hotel}", "/hotels/1"},
+	{"doubleWildcard", "/docs/**/*.html", "/docs/cvs/other/commit.html"},
+	{"caseInsensitive", "/DOCS/*.HTML", "/docs/commit.html"},
+}
+
+func Benchmark_match(b *testing.B) {
+	for _, bm := range matchBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			pathMatcher := NewAntPathMatcherWithOptions(WithCaseSensitive(bm.name != "caseInsensitive"))
+			if !pathMatcher.Match(bm.pattern, bm.path) {
+				b.Fatal("expected", bm.pattern, "to match", bm.path)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pathMatcher.Match(bm.pattern, bm.path)
+			}
+		})
+	}
+}
+
+// Benchmark_stringMatcher compares the glob fast path with the regexp the
+// same segments used to be compiled to.
+func Benchmark_stringMatcher(b *testing.B) {
+	segments := [][2]string{{"*.html", "commit.html"}, {"commit*", "commit.html"}, {"*mmi*", "commit.html"},
+		{"c?mmit.html", "commit.html"}, {"c*t.h*l", "commit.html"}}
+	for _, segment := range segments {
+		matcher := NewAntPathStringMatcher(segment[0])
+		re := globRegexp(segment[0], true)
+		b.Run(segment[0]+"/glob", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				matcher.matchStrings(segment[1], nil)
+			}
+		})
+		b.Run(segment[0]+"/regexp", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				strs := re.FindStringSubmatch(segment[1])
+				_ = len(strs) > 0 && strs[0] == segment[1]
+			}
+		})
+	}
+}
+
+// Benchmark_doubleWildcards matches patterns with several "**" against deep
+// paths that keep almost matching; ns/op should grow linearly with depth.
+func Benchmark_doubleWildcards(b *testing.B) {
+	for _, depth := range []int{16, 256, 4096} {
+		cases := []struct {
+			name    string
+			pattern string
+			path    string
+		}{
+			{"scattered", "/**/a/**/b/**/c/**", strings.Repeat("/x", depth) + "/a/b"},
+			{"run", "/**/a/a/a/a/b/**", strings.Repeat("/a", depth)},
+			{"wildcardRun", "/**/*a/*a/*a/*b/**", strings.Repeat("/aaaa", depth)},
+			{"variables", "/**/{x}/{y}/z/**", strings.Repeat("/y", depth)},
+		}
+		for _, c := range cases {
+			b.Run(c.name+"/"+strconv.Itoa(depth), func(b *testing.B) {
+				pathMatcher := NewAntPathMatcher()
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					pathMatcher.Match(c.pattern, c.path)
+				}
+			})
+		}
+	}
+}
+
+// patternSetBenchmarkPatterns returns n patterns shaped like the rules of an
+// authorization middleware.
+func patternSetBenchmarkPatterns(n int) []string {
+	patterns := make([]string, n)
+	for k := range patterns {
+		id := strconv.Itoa(k)
+		switch k % 4 {
+		case 0:
+			patterns[k] = "/api/v" + strconv.Itoa(k%3) + "/resource" + id + "/{id}"
+		case 1:
+			patterns[k] = "/static/app" + id + "/**/*.js"
+		case 2:
+			patterns[k] = "/users/{user}/items" + id + "/*"
+		default:
+			patterns[k] = "/files" + id + "/**"
+		}
+	}
+	return patterns
+}
+
+var patternSetBenchmarkPaths = []string{"/api/v2/resource5000/42", "/static/app9001/js/vendor/main.js",
+	"/users/george/items9998/1", "/files7/a/b/c", "/nothing/here"}
+
+func Benchmark_patternSet(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		patterns := patternSetBenchmarkPatterns(size)
+		set, err := NewPatternSet(patterns)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run("PatternSet/"+strconv.Itoa(size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				set.Matches(patternSetBenchmarkPaths[i%len(patternSetBenchmarkPaths)])
+			}
+		})
+		pathMatcher := NewAntPathMatcher()
+		b.Run("AntPathMatcher/"+strconv.Itoa(size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				path := patternSetBenchmarkPaths[i%len(patternSetBenchmarkPaths)]
+				for _, pattern := range patterns {
+					pathMatcher.Match(pattern, path)
+				}
+			}
+		})
+	}
+}
+
+func Benchmark_newPatternSet(b *testing.B) {
+	patterns := patternSetBenchmarkPatterns(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewPatternSet(patterns)
+	}
+}
@@ -0,0 +1,88 @@
+package antpathmatcher
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// @Author :George
+// @File: cachestats
+// @Version: 1.0.0
+// @Date 2026/10/18 15:30
+
+type CacheStats struct {
+	TokenizedPatterns CacheCounters `json:"tokenizedPatterns"`
+	StringMatchers    CacheCounters `json:"stringMatchers"`
+	// Deactivated is true when the matcher turned its cache off after seeing
+	// more than CACHE_TURNOFF_THRESHOLD distinct patterns.
+	Deactivated bool `json:"deactivated"`
+}
+
+type CacheCounters struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Entries   int    `json:"entries"`
+	Evictions uint64 `json:"evictions"`
+}
+
+// cacheCounters is shared by all states of one matcher, so the statistics
+// survive configuration changes.
+type cacheCounters struct {
+	tokenizedPatternHits      uint64
+	tokenizedPatternMisses    uint64
+	tokenizedPatternEvictions uint64
+	stringMatcherHits         uint64
+	stringMatcherMisses       uint64
+	stringMatcherEvictions    uint64
+}
+
+// retire accounts for the evictions of the caches of a state being replaced.
+func (c *cacheCounters) retire(s *matcherState) {
+	atomic.AddUint64(&c.tokenizedPatternEvictions, evictions(s.tokenizedPatternCache))
+	atomic.AddUint64(&c.stringMatcherEvictions, evictions(s.stringMatcherCache))
+}
+
+func evictions(cache PatternCache) uint64 {
+	if evicting, ok := cache.(EvictingPatternCache); ok {
+		return evicting.Evictions()
+	}
+	return 0
+}
+
+func (a *AntPathMatcher) CacheStats() CacheStats {
+	s := a.load()
+	c := s.counters
+	return CacheStats{
+		TokenizedPatterns: CacheCounters{
+			Hits:      atomic.LoadUint64(&c.tokenizedPatternHits),
+			Misses:    atomic.LoadUint64(&c.tokenizedPatternMisses),
+			Entries:   s.tokenizedPatternCache.Len(),
+			Evictions: atomic.LoadUint64(&c.tokenizedPatternEvictions) + evictions(s.tokenizedPatternCache),
+		},
+		StringMatchers: CacheCounters{
+			Hits:      atomic.LoadUint64(&c.stringMatcherHits),
+			Misses:    atomic.LoadUint64(&c.stringMatcherMisses),
+			Entries:   s.stringMatcherCache.Len(),
+			Evictions: atomic.LoadUint64(&c.stringMatcherEvictions) + evictions(s.stringMatcherCache),
+		},
+		Deactivated: s.autoDeactivated,
+	}
+}
+
+// CacheStatsVar returns an expvar.Var reporting CacheStats as JSON, e.g.
+// expvar.Publish("antpathmatcher", matcher.CacheStatsVar()).
+func (a *AntPathMatcher) CacheStatsVar() CacheStatsVar {
+	return CacheStatsVar{matcher: a}
+}
+
+type CacheStatsVar struct {
+	matcher *AntPathMatcher
+}
+
+func (v CacheStatsVar) String() string {
+	b, err := json.Marshal(v.matcher.CacheStats())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
@@ -0,0 +1,79 @@
+package antpathmatcher
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+// @Author :George
+// @File: cachestats_test
+// @Version: 1.0.0
+// @Date 2026/10/18 15:50
+
+func Test_cacheStats(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	pathMatcher.Match("/hotels/*", "/hotels/1")
+	pathMatcher.Match("/hotels/*", "/hotels/2")
+	stats := pathMatcher.CacheStats()
+	e.Equal(stats.TokenizedPatterns, CacheCounters{Hits: 1, Misses: 1, Entries: 1})
+	// "hotels" and "*" are each looked up twice
+	e.Equal(stats.StringMatchers, CacheCounters{Hits: 2, Misses: 2, Entries: 2})
+	e.False(stats.Deactivated)
+
+	// counters survive configuration changes, entries do not
+	pathMatcher.SetCaseSensitive(false)
+	stats = pathMatcher.CacheStats()
+	e.Equal(stats.TokenizedPatterns, CacheCounters{Hits: 1, Misses: 1, Entries: 0})
+}
+
+func Test_cacheStatsEvictions(t *testing.T) {
+	pathMatcher = NewAntPathMatcherWithOptions(WithLRUPatternCache(4))
+	e := assert.New(t)
+	for i := 0; i < 10; i++ {
+		pathMatcher.Match("/"+strconv.Itoa(i), "/"+strconv.Itoa(i))
+	}
+	stats := pathMatcher.CacheStats()
+	e.Equal(stats.TokenizedPatterns.Entries, 4)
+	e.Equal(stats.TokenizedPatterns.Evictions, uint64(6))
+
+	pathMatcher.SetTrimTokens(true)
+	pathMatcher.Match("/a", "/a")
+	stats = pathMatcher.CacheStats()
+	e.Equal(stats.TokenizedPatterns.Entries, 1)
+	e.Equal(stats.TokenizedPatterns.Evictions, uint64(6))
+}
+
+func Test_cacheStatsDeactivated(t *testing.T) {
+	cacheTurnoffThreshold = 16
+	defer func() { cacheTurnoffThreshold = CACHE_TURNOFF_THRESHOLD }()
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	for i := 0; i <= cacheTurnoffThreshold+1; i++ {
+		pathMatcher.MatchStart("/"+strconv.Itoa(i)+"/**", "/x")
+	}
+	stats := pathMatcher.CacheStats()
+	e.True(stats.Deactivated)
+	e.Equal(stats.TokenizedPatterns.Entries, 0)
+
+	pathMatcher.SetCachePatterns(true)
+	e.False(pathMatcher.CacheStats().Deactivated)
+}
+
+func Test_cacheStatsVar(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	// expvar names can only be published once, also across -count runs
+	name := fmt.Sprintf("Test_cacheStatsVar/%p", pathMatcher)
+	expvar.Publish(name, pathMatcher.CacheStatsVar())
+	pathMatcher.Match("/hotels/*", "/hotels/1")
+
+	stats := CacheStats{}
+	e.Nil(json.Unmarshal([]byte(expvar.Get(name).String()), &stats))
+	e.Equal(stats, pathMatcher.CacheStats())
+	e.Equal(stats.TokenizedPatterns.Misses, uint64(1))
+}
@@ -0,0 +1,36 @@
+package antpathmatcher
+
+import "errors"
+
+// @Author :George
+// @File: errors
+// @Version: 1.0.0
+// @Date 2026/10/18 10:04
+
+var (
+	ErrNoMatch                = errors.New("antpathmatcher: pattern does not match path")
+	ErrIncompatibleExtensions = errors.New("antpathmatcher: cannot combine patterns with different file extensions")
+	ErrCapturingGroup         = errors.New("antpathmatcher: capturing groups are not allowed in URI template variables")
+	ErrCapturingPattern       = errors.New("antpathmatcher: capturing patterns are not supported")
+	ErrInvalidPattern         = errors.New("antpathmatcher: invalid pattern")
+	ErrUnconvertiblePattern   = errors.New("antpathmatcher: pattern cannot be converted")
+)
+
+// matchError keeps the message the panicking methods have always used while
+// still letting callers test for the sentinel with errors.Is.
+type matchError struct {
+	kind error
+	msg  string
+}
+
+func newError(kind error, msg string) error {
+	return &matchError{kind: kind, msg: msg}
+}
+
+func (e *matchError) Error() string {
+	return e.msg
+}
+
+func (e *matchError) Unwrap() error {
+	return e.kind
+}
@@ -0,0 +1,155 @@
+package antpathmatcher
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// @Author :George
+// @File: glob
+// @Version: 1.0.0
+// @Date 2026/10/18 16:20
+
+// globKind selects the hand-written matcher an AntPathStringMatcher uses
+// instead of a regexp. Like the regexp it replaces, '*' and '?' never match
+// a newline.
+type globKind int
+
+const (
+	globNone      globKind = iota
+	globAny                // "*"
+	globPrefix             // "abc*"
+	globSuffix             // "*abc"
+	globInfix              // "*abc*"
+	globWildcards          // any other mix of literals, '*' and '?'
+	globVariable           // "{name}" with the default variable pattern
+)
+
+func (a *AntPathStringMatcher) initGlob(wildcards []string, defaultVariablePattern string) bool {
+	pattern := a.rawPattern
+	if len(wildcards) == 1 && len(wildcards[0]) == len(pattern) && pattern[0] == '{' {
+		if defaultVariablePattern != DEFAULT_VARIABLE_PATTERN || strings.IndexByte(pattern, ':') != -1 ||
+			strings.HasPrefix(a.variableNames[0], "*") {
+			return false
+		}
+		a.glob = globVariable
+		return true
+	}
+	for k := range wildcards {
+		if wildcards[k] != "*" && wildcards[k] != "?" {
+			return false
+		}
+	}
+	stars, last := strings.Count(pattern, "*"), len(pattern)-1
+	switch {
+	case stars == len(pattern):
+		a.glob = globAny
+	case !a.caseSensitive || strings.IndexByte(pattern, '?') != -1:
+		a.glob = globWildcards
+	case stars == 1 && pattern[last] == '*':
+		a.glob, a.literal = globPrefix, pattern[:last]
+	case stars == 1 && pattern[0] == '*':
+		a.glob, a.literal = globSuffix, pattern[1:]
+	case stars == 2 && pattern[0] == '*' && pattern[last] == '*' && strings.IndexByte(pattern, '\n') == -1:
+		a.glob, a.literal = globInfix, pattern[1:last]
+	default:
+		a.glob = globWildcards
+	}
+	return true
+}
+
+func (a *AntPathStringMatcher) matchGlob(str string, uriTemplateVariables map[string]string) bool {
+	switch a.glob {
+	case globAny:
+		return strings.IndexByte(str, '\n') == -1
+	case globPrefix:
+		return strings.HasPrefix(str, a.literal) && strings.IndexByte(str[len(a.literal):], '\n') == -1
+	case globSuffix:
+		return strings.HasSuffix(str, a.literal) && strings.IndexByte(str[:len(str)-len(a.literal)], '\n') == -1
+	case globInfix:
+		return strings.Contains(str, a.literal) && strings.IndexByte(str, '\n') == -1
+	case globVariable:
+		if uriTemplateVariables != nil {
+			uriTemplateVariables[a.variableNames[0]] = str
+		}
+		return true
+	default:
+		return globMatch(a.rawPattern, str, a.caseSensitive)
+	}
+}
+
+// globMatch matches str against a pattern of literals, '*' and '?' without
+// allocating. When a literal does not match, only the most recent '*' is
+// extended, which keeps the worst case at O(len(pattern) * len(str)).
+func globMatch(pattern, str string, caseSensitive bool) bool {
+	px, sx := 0, 0
+	nextPx, nextSx := 0, -1
+	for px < len(pattern) || sx < len(str) {
+		if px < len(pattern) {
+			switch pattern[px] {
+			case '*':
+				nextPx, nextSx = px, sx
+				px++
+				continue
+			case '?':
+				if sx < len(str) && str[sx] != '\n' {
+					px++
+					sx += runeWidth(str, sx)
+					continue
+				}
+			default:
+				if sx < len(str) && pattern[px] < utf8.RuneSelf && str[sx] < utf8.RuneSelf {
+					if pattern[px] == str[sx] || !caseSensitive && asciiLower(pattern[px]) == asciiLower(str[sx]) {
+						px++
+						sx++
+						continue
+					}
+				} else if sx < len(str) {
+					pr, pw := utf8.DecodeRuneInString(pattern[px:])
+					sr, sw := utf8.DecodeRuneInString(str[sx:])
+					if pattern[px:px+pw] == str[sx:sx+sw] || !caseSensitive && pr != utf8.RuneError && equalFoldRune(pr, sr) {
+						px += pw
+						sx += sw
+						continue
+					}
+				}
+			}
+		}
+		// Mismatch, let the last '*' swallow one more rune and retry.
+		if nextSx >= 0 && nextSx < len(str) && str[nextSx] != '\n' {
+			nextSx += runeWidth(str, nextSx)
+			px, sx = nextPx+1, nextSx
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func runeWidth(str string, pos int) int {
+	if str[pos] < utf8.RuneSelf {
+		return 1
+	}
+	_, w := utf8.DecodeRuneInString(str[pos:])
+	return w
+}
+
+func asciiLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+func equalFoldRune(r1, r2 rune) bool {
+	if r1 == r2 {
+		return true
+	}
+	for r := unicode.SimpleFold(r1); r != r1; r = unicode.SimpleFold(r) {
+		if r == r2 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package antpathmatcher
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// @Author :George
+// @File: glob_test
+// @Version: 1.0.0
+// @Date 2026/10/18 16:50
+
+// globRegexp builds the regexp the matcher used before the glob fast path.
+func globRegexp(pattern string, caseSensitive bool) *regexp.Regexp {
+	builder := strings.Builder{}
+	if !caseSensitive {
+		builder.WriteString("(?i)")
+	}
+	for _, r := range pattern {
+		switch r {
+		case '?':
+			builder.WriteString(".")
+		case '*':
+			builder.WriteString(".*")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return regexp.MustCompile(builder.String())
+}
+
+func Test_globMatchesRegexp(t *testing.T) {
+	e := assert.New(t)
+	patterns := []string{"*", "**", "*.html", "test*", "*test*", "test*aaa", "t?st", "??st", "*.*", "a*b*c",
+		"?*", "*?", "*?*x", "é*", "*é?", "Straße*", "*K*", "a\nb*", "*a\nb*", "x*y?z*"}
+	strs := []string{"", "test", "testTest", "tsttest", "AnothertestTest", "testblaaaa", "testblaaab", "test.html",
+		"a.b.c", "abc", "aXbYc", "acb", "é", "éé", "Straße", "STRASSE", "straßex", "K", "k", "a\nb", "xa\nbx",
+		"x\ny", "\n", "xyz", "x1y2z3", "test\n", "\xff", "é\xff"}
+	for _, caseSensitive := range []bool{true, false} {
+		for _, pattern := range patterns {
+			matcher, err := newAntPathStringMatcher(pattern, caseSensitive, DEFAULT_VARIABLE_PATTERN)
+			e.Nil(err)
+			e.NotEqual(matcher.glob, globNone, pattern)
+			e.Nil(matcher.pattern, pattern)
+			re := globRegexp(pattern, caseSensitive)
+			for _, str := range strs {
+				expected := re.FindString(str) == str && re.MatchString(str)
+				e.Equal(matcher.matchStrings(str, nil), expected, "pattern %q, str %q, caseSensitive %v", pattern, str, caseSensitive)
+			}
+		}
+	}
+}
+
+func Test_globKinds(t *testing.T) {
+	e := assert.New(t)
+	kinds := map[string]globKind{
+		"*":        globAny,
+		"**":       globAny,
+		"test*":    globPrefix,
+		"*.html":   globSuffix,
+		"*test*":   globInfix,
+		"t?st":     globWildcards,
+		"test*aaa": globWildcards,
+		"{hotel}":  globVariable,
+		"abc":      globNone,
+	}
+	for pattern, kind := range kinds {
+		e.Equal(NewAntPathStringMatcher(pattern).glob, kind, pattern)
+	}
+	e.Equal(NewAntPathStringMatcherWithCaseSensitive("test*", false).glob, globWildcards)
+
+	// everything else still goes through a regexp
+	for _, pattern := range []string{"{hotel:[0-9]+}", "{name}.html", "{*path}"} {
+		matcher := NewAntPathStringMatcher(pattern)
+		e.Equal(matcher.glob, globNone, pattern)
+		e.NotNil(matcher.pattern, pattern)
+	}
+	matcher, err := newAntPathStringMatcher("{hotel}", true, "([0-9]+)")
+	e.Nil(err)
+	e.Equal(matcher.glob, globNone)
+}
+
+func Test_globVariable(t *testing.T) {
+	e := assert.New(t)
+	matcher := NewAntPathStringMatcher("{hotel}")
+	variables := make(map[string]string)
+	e.True(matcher.matchStrings("x\ny", variables))
+	e.Equal(variables["hotel"], "x\ny")
+	e.True(matcher.matchStrings("", nil))
+}
@@ -1,6 +1,6 @@
 module github.com/georgeJobs/go-antpathmatcher
 
-go 1.18
+go 1.22
 
 require (
 	github.com/stretchr/testify v1.8.4
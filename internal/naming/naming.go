@@ -0,0 +1,51 @@
+package naming
+
+import (
+	"strconv"
+)
+
+// @Author :George
+// @File: naming
+// @Version: 1.0.0
+// @Date 2026/10/19 03:00
+
+const (
+	// CATCH_ALL_NAME names the variable a trailing "**" becomes.
+	CATCH_ALL_NAME = "path"
+	// SINGLE_WILDCARD_PREFIX followed by the index of its segment names the
+	// variable a "*" segment becomes.
+	SINGLE_WILDCARD_PREFIX = "seg"
+)
+
+// Names are the variable names of a route, from which conversions pick names
+// for the wildcards of Ant patterns. The zero value is ready to use.
+type Names struct {
+	taken map[string]bool
+}
+
+func (n *Names) Add(name string) {
+	if n.taken == nil {
+		n.taken = make(map[string]bool)
+	}
+	n.taken[name] = true
+}
+
+// Unique returns name, or name followed by as many "_" as it takes not to
+// clash with the other names, and adds it.
+func (n *Names) Unique(name string) string {
+	for n.taken[name] {
+		name += "_"
+	}
+	n.Add(name)
+	return name
+}
+
+// Wildcard names the "*" of segment k.
+func (n *Names) Wildcard(k int) string {
+	return n.Unique(SINGLE_WILDCARD_PREFIX + strconv.Itoa(k))
+}
+
+// CatchAll names a trailing "**".
+func (n *Names) CatchAll() string {
+	return n.Unique(CATCH_ALL_NAME)
+}
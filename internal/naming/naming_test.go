@@ -0,0 +1,24 @@
+package naming
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// @Author :George
+// @File: naming_test
+// @Version: 1.0.0
+// @Date 2026/10/19 03:00
+
+func Test_names(t *testing.T) {
+	e := assert.New(t)
+	names := Names{}
+	e.Equal(names.Unique("id"), "id")
+	names.Add("seg1")
+	names.Add("path")
+	e.Equal(names.Wildcard(0), "seg0")
+	e.Equal(names.Wildcard(1), "seg1_")
+	e.Equal(names.CatchAll(), "path_")
+	e.Equal(names.CatchAll(), "path__")
+	e.Equal(names.Unique("id"), "id_")
+}
@@ -0,0 +1,97 @@
+package antpathmatcher
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"strings"
+)
+
+// @Author :George
+// @File: options
+// @Version: 1.0.0
+// @Date 2026/10/18 10:02
+
+type Option func(*options)
+
+type options struct {
+	caseSensitive          bool
+	trimTokens             bool
+	pathSeparator          string
+	cachePatterns          null.Bool
+	defaultVariablePattern string
+	newPatternCache        func() PatternCache
+	autoDeactivated        bool
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		caseSensitive:          true,
+		trimTokens:             false,
+		pathSeparator:          DEFAULT_PATH_SEPARATOR,
+		defaultVariablePattern: DEFAULT_VARIABLE_PATTERN,
+	}
+	for k := range opts {
+		opts[k](&o)
+	}
+	return o
+}
+
+func WithCaseSensitive(caseSensitive bool) Option {
+	return func(o *options) {
+		o.caseSensitive = caseSensitive
+	}
+}
+
+func WithTrimTokens(trimTokens bool) Option {
+	return func(o *options) {
+		o.trimTokens = trimTokens
+	}
+}
+
+func WithPathSeparator(pathSeparator string) Option {
+	return func(o *options) {
+		if strings.TrimSpace(pathSeparator) == "" {
+			pathSeparator = DEFAULT_PATH_SEPARATOR
+		}
+		o.pathSeparator = pathSeparator
+	}
+}
+
+// WithCachePatterns forces pattern caching on or off. Without it the matcher
+// caches until CACHE_TURNOFF_THRESHOLD distinct patterns have been seen.
+func WithCachePatterns(cachePatterns bool) Option {
+	return func(o *options) {
+		o.cachePatterns.SetValid(cachePatterns)
+		o.autoDeactivated = false
+	}
+}
+
+// WithDefaultVariablePattern sets the regex used for "{name}" variables that
+// do not declare their own, e.g. "[^.]+". It must not contain capturing groups.
+func WithDefaultVariablePattern(variablePattern string) Option {
+	return func(o *options) {
+		o.defaultVariablePattern = toDefaultVariablePattern(variablePattern)
+	}
+}
+
+func toDefaultVariablePattern(variablePattern string) string {
+	if variablePattern == "" {
+		return DEFAULT_VARIABLE_PATTERN
+	}
+	return "(" + variablePattern + ")"
+}
+
+// WithPatternCache makes the matcher keep tokenized patterns and string
+// matchers in caches created by newCache, one for each kind. A fresh pair is
+// created whenever the configuration changes. Custom caches are never turned
+// off by CACHE_TURNOFF_THRESHOLD; bounding them is up to the implementation.
+func WithPatternCache(newCache func() PatternCache) Option {
+	return func(o *options) {
+		o.newPatternCache = newCache
+	}
+}
+
+func WithLRUPatternCache(capacity int) Option {
+	return WithPatternCache(func() PatternCache {
+		return NewLRUPatternCache(capacity)
+	})
+}
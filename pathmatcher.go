@@ -14,3 +14,11 @@ type PathMatcher interface {
 	GetPatternComparator(string) Comparator
 	Combine(string, string) string
 }
+
+type SafePathMatcher interface {
+	PathMatcher
+	TryMatch(string, string) (bool, error)
+	TryMatchStart(string, string) (bool, error)
+	TryExtractUriTemplateVariables(string, string) (map[string]string, error)
+	TryCombine(string, string) (string, error)
+}
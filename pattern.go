@@ -0,0 +1,111 @@
+package antpathmatcher
+
+import "strings"
+
+// @Author :George
+// @File: pattern
+// @Version: 1.0.0
+// @Date 2026/10/18 10:05
+
+// Pattern is a pattern that has been tokenized and compiled once by Compile.
+// It is immutable and safe for concurrent use.
+type Pattern struct {
+	pattern  string
+	state    *matcherState
+	segments []string
+	matchers []*AntPathStringMatcher
+}
+
+// Compile only rejects the patterns that AntPathMatcher.Match would panic on:
+// an invalid variable regex, a capturing group or a capturing pattern. Like
+// Match, it accepts an unclosed "{" as a literal and a repeated variable name,
+// which Validate does report.
+func Compile(pattern string, opts ...Option) (*Pattern, error) {
+	errs := Validate(pattern, opts...)
+	for k := range errs {
+		if errs[k].fatal() {
+			return nil, &errs[k]
+		}
+	}
+	o := newOptions(opts...)
+	o.cachePatterns.SetValid(false)
+	state := newMatcherState(nil, o, nil)
+	segments := state.tokenizePath(pattern)
+	matchers := make([]*AntPathStringMatcher, len(segments))
+	for k := range segments {
+		m, err := newAntPathStringMatcher(segments[k], state.caseSensitive, state.defaultVariablePattern)
+		if err != nil {
+			return nil, newError(ErrInvalidPattern, "Invalid pattern \""+pattern+"\": "+err.Error())
+		}
+		if m.pattern != nil && m.pattern.NumSubexp() != len(m.variableNames) {
+			return nil, newError(ErrCapturingGroup, "The number of capturing groups in the pattern segment "+
+				segments[k]+" of \""+pattern+"\" does not match the number of URI template variables it defines. "+
+				"Use non-capturing groups instead.")
+		}
+		for i := range m.variableNames {
+			if strings.HasPrefix(m.variableNames[i], "*") {
+				return nil, newError(ErrCapturingPattern, "Capturing patterns ("+m.variableNames[i]+") are not "+
+					"supported by the AntPathMatcher")
+			}
+		}
+		matchers[k] = m
+	}
+	return &Pattern{
+		pattern:  pattern,
+		state:    state,
+		segments: segments,
+		matchers: matchers,
+	}, nil
+}
+
+func MustCompile(pattern string, opts ...Option) *Pattern {
+	p, err := Compile(pattern, opts...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+func (p *Pattern) String() string {
+	return p.pattern
+}
+
+func (p *Pattern) CaseSensitive() bool {
+	return p.state.caseSensitive
+}
+
+func (p *Pattern) Match(path string) bool {
+	result, _ := p.doMatch(path, true, nil)
+	return result
+}
+
+func (p *Pattern) MatchStart(path string) bool {
+	result, _ := p.doMatch(path, false, nil)
+	return result
+}
+
+func (p *Pattern) ExtractPathWithinPattern(path string) string {
+	return p.state.extractPathWithinPattern(p.pattern, p.segments, path)
+}
+
+func (p *Pattern) ExtractUriTemplateVariables(path string) (map[string]string, error) {
+	variables := make(map[string]string)
+	result, err := p.doMatch(path, true, variables)
+	if err != nil {
+		return nil, err
+	}
+	if !result {
+		return nil, newError(ErrNoMatch, "Pattern \""+p.pattern+"\" is not a match for \""+path+"\"")
+	}
+	return variables, nil
+}
+
+// doMatch can only fail for segments that Compile has already rejected,
+// so Match and MatchStart safely ignore its error.
+func (p *Pattern) doMatch(path string, fullMatch bool, uriTemplateVariables map[string]string) (bool, error) {
+	sep := p.state.pathSeparator
+	if strings.HasPrefix(path, sep) != strings.HasPrefix(p.pattern, sep) {
+		return false, nil
+	}
+	return p.state.matchTokenized(p.pattern, p.segments, p.matchers, path, fullMatch, uriTemplateVariables)
+}
@@ -0,0 +1,132 @@
+package antpathmatcher
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+// @Author :George
+// @File: pattern_test
+// @Version: 1.0.0
+// @Date 2026/10/18 10:20
+
+func Test_compile(t *testing.T) {
+	e := assert.New(t)
+	p, err := Compile("/hotels/{hotel}/bookings/*.html")
+	e.Nil(err)
+	e.Equal(p.String(), "/hotels/{hotel}/bookings/*.html")
+	e.True(p.Match("/hotels/1/bookings/2.html"))
+	e.False(p.Match("/hotels/1/bookings/2.txt"))
+	e.False(p.Match("hotels/1/bookings/2.html"))
+	e.True(p.MatchStart("/hotels/1"))
+	e.False(p.MatchStart("/motels/1"))
+
+	p = MustCompile("/bla/**/bla")
+	e.True(p.Match("/bla/testing/testing/bla"))
+	e.True(p.Match("/bla/testing/testing/bla/bla"))
+	e.False(p.Match("/bla/testing/testing/blah"))
+}
+
+func Test_compileMatchesAntPathMatcher(t *testing.T) {
+	pathMatcher = NewAntPathMatcher()
+	e := assert.New(t)
+	cases := [][2]string{
+		{"test", "test"},
+		{"/test", "test"},
+		{"t?st", "test"},
+		{"test*", "test/"},
+		{"test/*", "test/"},
+		{"*.*", "test.test.test"},
+		{"/**/*", "/testing/testing"},
+		{"/*bla*/**/bla/**", "/XXXblaXXXX/testing/testing/bla/testing/testing/"},
+		{"*bla*/**/bla/*", "XXXblaXXXX/testing/testing/bla/testing/testing"},
+		{"/x/x/**/bla", "/x/x/x/"},
+		{"/foo/bar/**", "/foo/bar"},
+		{"/*/foo", "/en/foo/"},
+		{"/{bla}.*", "/testing.html"},
+		{"", ""},
+	}
+	for k := range cases {
+		p, err := Compile(cases[k][0])
+		e.Nil(err)
+		e.Equal(p.Match(cases[k][1]), pathMatcher.Match(cases[k][0], cases[k][1]), cases[k])
+		e.Equal(p.MatchStart(cases[k][1]), pathMatcher.MatchStart(cases[k][0], cases[k][1]), cases[k])
+	}
+}
+
+func Test_compileWithOptions(t *testing.T) {
+	e := assert.New(t)
+	p := MustCompile("/group/{groupName}/members", WithCaseSensitive(false))
+	e.True(p.Match("/Group/Sales/Members"))
+	e.False(p.CaseSensitive())
+	e.True(MustCompile("/group").CaseSensitive())
+
+	p = MustCompile("/foo/bar", WithTrimTokens(true))
+	e.True(p.MatchStart("/foo /bar"))
+
+	p = MustCompile(".bla.**.bla", WithPathSeparator("."))
+	e.True(p.Match(".bla.testing.testing.bla"))
+}
+
+func Test_compileInvalidPattern(t *testing.T) {
+	e := assert.New(t)
+	_, err := Compile("/users/{id:[0-9}")
+	e.True(errors.Is(err, ErrInvalidPattern))
+
+	// SPR-8455
+	_, err = Compile("/web/{id:foo(bar)?}")
+	e.True(errors.Is(err, ErrCapturingGroup))
+
+	_, err = Compile("/files/{*path}")
+	e.True(errors.Is(err, ErrCapturingPattern))
+	e.Contains(err.Error(), "*path")
+
+	e.Panics(func() { MustCompile("/users/{id:[0-9}") })
+}
+
+func Test_patternExtractUriTemplateVariables(t *testing.T) {
+	e := assert.New(t)
+	p := MustCompile("{symbolicName:[\\w\\.]+}-sources-{version:[\\d\\.]+}-{year:\\d{4}}{month:\\d{2}}{day:\\d{2}}.jar")
+	result, err := p.ExtractUriTemplateVariables("com.example-sources-1.0.0-20100220.jar")
+	e.Nil(err)
+	e.Equal(result["symbolicName"], "com.example")
+	e.Equal(result["version"], "1.0.0")
+	e.Equal(result["year"], "2010")
+	e.Equal(result["month"], "02")
+	e.Equal(result["day"], "20")
+
+	result, err = MustCompile("/hotels/{hotel}").ExtractUriTemplateVariables("/motels/1")
+	e.Nil(result)
+	e.True(errors.Is(err, ErrNoMatch))
+}
+
+func Test_patternExtractPathWithinPattern(t *testing.T) {
+	e := assert.New(t)
+	e.Equal(MustCompile("/docs/**/*.html").ExtractPathWithinPattern("/docs/cvs/commit.html"), "cvs/commit.html")
+	e.Equal(MustCompile("/d?cs/*").ExtractPathWithinPattern("/docs/cvs/commit"), "docs/cvs/commit")
+	e.Equal(MustCompile("/docs/commit.html").ExtractPathWithinPattern("/docs/commit.html"), "")
+}
+
+func Test_patternConcurrentUse(t *testing.T) {
+	p := MustCompile("/hotels/{hotel}/**/*.html")
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if !p.Match("/hotels/1/a/b/c.html") {
+					t.Error("expected match")
+					return
+				}
+				if vars, err := p.ExtractUriTemplateVariables("/hotels/1/a/b/c.html"); err != nil || vars["hotel"] != "1" {
+					t.Error("unexpected variables", vars, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+package antpathmatcher
+
+import (
+	"container/list"
+	"github.com/georgeJobs/go-antpathmatcher/pkg"
+	"sync"
+)
+
+// @Author :George
+// @File: patterncache
+// @Version: 1.0.0
+// @Date 2026/10/18 14:40
+
+// PatternCache stores tokenized patterns ([]string) and compiled
+// *AntPathStringMatcher values keyed by pattern. Implementations must be
+// safe for concurrent use.
+type PatternCache interface {
+	Load(key string) (value any, ok bool)
+	LoadOrStore(key string, value any) (actual any, loaded bool)
+	Len() int
+}
+
+// EvictingPatternCache is implemented by caches that drop entries on their
+// own, so that CacheStats can report how many were evicted.
+type EvictingPatternCache interface {
+	PatternCache
+	Evictions() uint64
+}
+
+//region syncMapPatternCache
+
+// syncMapPatternCache is the default unbounded cache. The matcher turns it off
+// once it grows past CACHE_TURNOFF_THRESHOLD entries.
+type syncMapPatternCache struct {
+	m pkg.MySyncMap
+}
+
+func newSyncMapPatternCache() PatternCache {
+	return &syncMapPatternCache{}
+}
+
+func (c *syncMapPatternCache) Load(key string) (any, bool) {
+	return c.m.Load(key)
+}
+
+func (c *syncMapPatternCache) LoadOrStore(key string, value any) (any, bool) {
+	return c.m.LoadOrStore(key, value)
+}
+
+func (c *syncMapPatternCache) Len() int {
+	return c.m.Len()
+}
+
+//endregion
+
+//region LRUPatternCache
+
+type LRUPatternCache struct {
+	mu        sync.Mutex
+	capacity  int
+	evictions uint64
+	ll        *list.List
+	items     map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value any
+}
+
+func NewLRUPatternCache(capacity int) *LRUPatternCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUPatternCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUPatternCache) Load(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*lruEntry).value, true
+	}
+	return nil, false
+}
+
+func (c *LRUPatternCache) LoadOrStore(key string, value any) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*lruEntry).value, true
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry{key: key, value: value})
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+		c.evictions++
+	}
+	return value, false
+}
+
+func (c *LRUPatternCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *LRUPatternCache) Evictions() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.evictions
+}
+
+//endregion
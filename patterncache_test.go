@@ -0,0 +1,103 @@
+package antpathmatcher
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// @Author :George
+// @File: patterncache_test
+// @Version: 1.0.0
+// @Date 2026/10/18 15:00
+
+func Test_lruPatternCache(t *testing.T) {
+	e := assert.New(t)
+	cache := NewLRUPatternCache(2)
+	cache.LoadOrStore("a", 1)
+	cache.LoadOrStore("b", 2)
+	_, ok := cache.Load("a")
+	e.True(ok)
+
+	actual, loaded := cache.LoadOrStore("c", 3)
+	e.False(loaded)
+	e.Equal(actual, 3)
+	e.Equal(cache.Len(), 2)
+	_, ok = cache.Load("b")
+	e.False(ok)
+	_, ok = cache.Load("a")
+	e.True(ok)
+
+	actual, loaded = cache.LoadOrStore("a", 4)
+	e.True(loaded)
+	e.Equal(actual, 1)
+}
+
+func Test_matcherWithLRUPatternCache(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher = NewAntPathMatcherWithOptions(WithLRUPatternCache(16))
+	for i := 0; i < 100; i++ {
+		e.True(pathMatcher.Match("/hotels/"+strconv.Itoa(i)+"/*", "/hotels/"+strconv.Itoa(i)+"/bookings"))
+	}
+	s := pathMatcher.load()
+	e.Equal(s.tokenizedPatternCache.Len(), 16)
+	e.Equal(s.stringMatcherCache.Len(), 16)
+	e.Equal(s.cachePatterns.Valid, false)
+
+	_, ok := s.tokenizedPatternCache.Load("/hotels/99/*")
+	e.True(ok)
+	_, ok = s.tokenizedPatternCache.Load("/hotels/0/*")
+	e.False(ok)
+}
+
+type countingPatternCache struct {
+	mu     sync.Mutex
+	values map[string]any
+	stores int
+}
+
+func (c *countingPatternCache) Load(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *countingPatternCache) LoadOrStore(key string, value any) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if actual, ok := c.values[key]; ok {
+		return actual, true
+	}
+	c.stores++
+	c.values[key] = value
+	return value, false
+}
+
+func (c *countingPatternCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.values)
+}
+
+func Test_matcherWithCustomPatternCache(t *testing.T) {
+	e := assert.New(t)
+	caches := make([]*countingPatternCache, 0)
+	pathMatcher = NewAntPathMatcherWithOptions(WithPatternCache(func() PatternCache {
+		cache := &countingPatternCache{values: make(map[string]any)}
+		caches = append(caches, cache)
+		return cache
+	}))
+	e.Len(caches, 2)
+	e.True(pathMatcher.Match("/hotels/*", "/hotels/1"))
+	e.True(pathMatcher.Match("/hotels/*", "/hotels/2"))
+	e.Equal(caches[0].stores, 1)
+	e.Equal(caches[1].stores, 2)
+
+	// a configuration change starts from empty caches
+	pathMatcher.SetCaseSensitive(false)
+	e.Len(caches, 4)
+	e.True(pathMatcher.Match("/hotels/*", "/HOTELS/1"))
+	e.Equal(caches[2].stores, 1)
+}
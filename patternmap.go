@@ -0,0 +1,169 @@
+package antpathmatcher
+
+import (
+	"sort"
+	"sync"
+)
+
+// @Author :George
+// @File: patternmap
+// @Version: 1.0.0
+// @Date 2026/10/18 18:10
+
+//region PatternMap
+
+// PatternMap associates values with patterns and looks them up by path,
+// preferring the most specific pattern as ordered by AntPatternComparator.
+// The patterns are indexed like in a PatternSet. A PatternMap is safe for
+// concurrent use; its zero value is not, use NewPatternMap.
+type PatternMap[T any] struct {
+	mu      sync.RWMutex
+	opts    []Option
+	index   patternIndex
+	entries []*patternMapEntry[T]
+	ids     map[string]int
+	deleted int
+}
+
+type patternMapEntry[T any] struct {
+	pattern *Pattern
+	value   T
+}
+
+// PatternMatch is a pattern of a PatternMap that matched a path, with its value
+// and the URI template variables it extracted from the path.
+type PatternMatch[T any] struct {
+	Pattern   string
+	Value     T
+	Variables map[string]string
+}
+
+func NewPatternMap[T any](opts ...Option) *PatternMap[T] {
+	return &PatternMap[T]{
+		opts:  opts,
+		index: newPatternIndex(newOptions(opts...)),
+		ids:   make(map[string]int),
+	}
+}
+
+// Put associates value with pattern, replacing the value it had if any. The
+// error is the one Compile returns for an invalid pattern.
+func (m *PatternMap[T]) Put(pattern string, value T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id, ok := m.ids[pattern]; ok {
+		m.entries[id].value = value
+		return nil
+	}
+	p, err := Compile(pattern, m.opts...)
+	if err != nil {
+		return err
+	}
+	id := len(m.entries)
+	m.entries = append(m.entries, &patternMapEntry[T]{pattern: p, value: value})
+	m.ids[pattern] = id
+	m.index.add(p, id)
+	return nil
+}
+
+// Get returns the value of the most specific pattern that matches path, the
+// one put first if several are equally specific.
+func (m *PatternMap[T]) Get(path string) (PatternMatch[T], bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	comparator := NewAntPatternComparator(path)
+	var best *patternMapEntry[T]
+	for _, id := range m.index.candidates(path) {
+		entry := m.entries[id]
+		if entry.pattern.Match(path) && (best == nil || comparator.Compare(entry.pattern.pattern, best.pattern.pattern) < 0) {
+			best = entry
+		}
+	}
+	if best == nil {
+		return PatternMatch[T]{}, false
+	}
+	return best.match(path), true
+}
+
+// GetAll returns every pattern that matches path, from the most to the least
+// specific.
+func (m *PatternMap[T]) GetAll(path string) []PatternMatch[T] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var matches []PatternMatch[T]
+	for _, id := range m.index.candidates(path) {
+		if entry := m.entries[id]; entry.pattern.Match(path) {
+			matches = append(matches, entry.match(path))
+		}
+	}
+	comparator := NewAntPatternComparator(path)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return comparator.Compare(matches[i].Pattern, matches[j].Pattern) < 0
+	})
+	return matches
+}
+
+// Delete removes pattern and reports whether it was present.
+func (m *PatternMap[T]) Delete(pattern string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id, ok := m.ids[pattern]
+	if !ok {
+		return false
+	}
+	m.index.remove(m.entries[id].pattern, id)
+	m.entries[id] = nil
+	delete(m.ids, pattern)
+	m.deleted++
+	if m.deleted*2 > len(m.entries) {
+		m.compact()
+	}
+	return true
+}
+
+// Range calls f for each pattern and its value in the order they were put,
+// until f returns false. f must not modify the map.
+func (m *PatternMap[T]) Range(f func(pattern string, value T) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, entry := range m.entries {
+		if entry != nil && !f(entry.pattern.pattern, entry.value) {
+			return
+		}
+	}
+}
+
+func (m *PatternMap[T]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.ids)
+}
+
+// compact drops the deleted entries and rebuilds the index without the nodes
+// they left behind.
+func (m *PatternMap[T]) compact() {
+	entries := make([]*patternMapEntry[T], 0, len(m.ids))
+	m.index = newPatternIndex(m.index.options)
+	for _, entry := range m.entries {
+		if entry != nil {
+			id := len(entries)
+			entries = append(entries, entry)
+			m.ids[entry.pattern.pattern] = id
+			m.index.add(entry.pattern, id)
+		}
+	}
+	m.entries = entries
+	m.deleted = 0
+}
+
+func (e *patternMapEntry[T]) match(path string) PatternMatch[T] {
+	variables := make(map[string]string)
+	e.pattern.doMatch(path, true, variables)
+	return PatternMatch[T]{
+		Pattern:   e.pattern.pattern,
+		Value:     e.value,
+		Variables: variables,
+	}
+}
+
+//endregion
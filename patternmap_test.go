@@ -0,0 +1,135 @@
+package antpathmatcher
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// @Author :George
+// @File: patternmap_test
+// @Version: 1.0.0
+// @Date 2026/10/18 18:30
+
+func Test_patternMap(t *testing.T) {
+	e := assert.New(t)
+	m := NewPatternMap[int]()
+	e.Nil(m.Put("/**", 1))
+	e.Nil(m.Put("/hotels/*", 2))
+	e.Nil(m.Put("/hotels/{hotel}", 3))
+	e.Nil(m.Put("/hotels/new", 4))
+	e.Equal(m.Len(), 4)
+
+	match, ok := m.Get("/hotels/new")
+	e.True(ok)
+	e.Equal(match, PatternMatch[int]{Pattern: "/hotels/new", Value: 4, Variables: map[string]string{}})
+
+	match, ok = m.Get("/hotels/1")
+	e.True(ok)
+	e.Equal(match, PatternMatch[int]{Pattern: "/hotels/{hotel}", Value: 3, Variables: map[string]string{"hotel": "1"}})
+
+	e.Equal(m.GetAll("/hotels/1"), []PatternMatch[int]{
+		{Pattern: "/hotels/{hotel}", Value: 3, Variables: map[string]string{"hotel": "1"}},
+		{Pattern: "/hotels/*", Value: 2, Variables: map[string]string{}},
+		{Pattern: "/**", Value: 1, Variables: map[string]string{}},
+	})
+
+	match, ok = m.Get("hotels")
+	e.False(ok)
+	e.Equal(match, PatternMatch[int]{})
+	e.Nil(m.GetAll("hotels"))
+
+	// replacing a value keeps the pattern in place
+	e.Nil(m.Put("/hotels/*", 5))
+	e.Equal(m.Len(), 4)
+	var patterns []string
+	var values []int
+	m.Range(func(pattern string, value int) bool {
+		patterns = append(patterns, pattern)
+		values = append(values, value)
+		return true
+	})
+	e.Equal(patterns, []string{"/**", "/hotels/*", "/hotels/{hotel}", "/hotels/new"})
+	e.Equal(values, []int{1, 5, 3, 4})
+
+	e.True(m.Delete("/hotels/{hotel}"))
+	e.False(m.Delete("/hotels/{hotel}"))
+	match, _ = m.Get("/hotels/1")
+	e.Equal(match.Pattern, "/hotels/*")
+	e.Equal(match.Value, 5)
+
+	e.True(errors.Is(m.Put("/hotels/{hotel:[0-9}", 6), ErrInvalidPattern))
+	e.Equal(m.Len(), 3)
+}
+
+func Test_patternMapRangeStops(t *testing.T) {
+	e := assert.New(t)
+	m := NewPatternMap[string]()
+	for _, pattern := range []string{"/a", "/b", "/c"} {
+		e.Nil(m.Put(pattern, pattern))
+	}
+	count := 0
+	m.Range(func(pattern, value string) bool {
+		count++
+		return pattern != "/b"
+	})
+	e.Equal(count, 2)
+}
+
+func Test_patternMapWithOptions(t *testing.T) {
+	e := assert.New(t)
+	m := NewPatternMap[string](WithCaseSensitive(false), WithPathSeparator("."))
+	e.Nil(m.Put("com.*.Service", "service"))
+	e.Nil(m.Put("com.example.**", "example"))
+	match, ok := m.Get("COM.EXAMPLE.SERVICE")
+	e.True(ok)
+	e.Equal(match.Value, "service")
+	e.Len(m.GetAll("com.example.Service"), 2)
+}
+
+func Test_patternMapDeleteCompacts(t *testing.T) {
+	e := assert.New(t)
+	m := NewPatternMap[int]()
+	for i := 0; i < 100; i++ {
+		e.Nil(m.Put("/items/"+strconv.Itoa(i)+"/*", i))
+	}
+	for i := 0; i < 90; i++ {
+		e.True(m.Delete("/items/" + strconv.Itoa(i) + "/*"))
+	}
+	e.Equal(m.Len(), 10)
+	e.Less(len(m.entries), 100)
+	for i := 0; i < 100; i++ {
+		match, ok := m.Get("/items/" + strconv.Itoa(i) + "/x")
+		e.Equal(ok, i >= 90, i)
+		if ok {
+			e.Equal(match.Value, i)
+		}
+	}
+	// a deleted pattern can be put again
+	e.Nil(m.Put("/items/0/*", 1000))
+	match, _ := m.Get("/items/0/x")
+	e.Equal(match.Value, 1000)
+}
+
+func Test_patternMapConcurrentUse(t *testing.T) {
+	m := NewPatternMap[int]()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				pattern := "/w" + strconv.Itoa(i) + "/" + strconv.Itoa(j%10) + "/**"
+				_ = m.Put(pattern, j)
+				m.Get("/w" + strconv.Itoa(i) + "/3/x")
+				m.GetAll("/w" + strconv.Itoa(i) + "/3/x")
+				if j%3 == 0 {
+					m.Delete(pattern)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
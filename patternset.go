@@ -0,0 +1,297 @@
+package antpathmatcher
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher/pkg"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// @Author :George
+// @File: patternset
+// @Version: 1.0.0
+// @Date 2026/10/18 17:05
+
+//region PatternSet
+
+// PatternSet matches a path against many patterns at once. The patterns are
+// indexed in a trie of their segments: literal segments are looked up by key
+// and only the wildcard and "**" branches are tried one by one, so a lookup
+// visits the part of the trie the path can actually reach instead of every
+// pattern. The candidates found that way are confirmed with Pattern.Match.
+// A PatternSet is immutable and safe for concurrent use.
+type PatternSet struct {
+	patternIndex
+	patterns []*Pattern
+}
+
+// NewPatternSet compiles patterns with opts and indexes them. Duplicate
+// patterns are only kept once; the first error returned by Compile, if any,
+// is returned.
+func NewPatternSet(patterns []string, opts ...Option) (*PatternSet, error) {
+	s := &PatternSet{patternIndex: newPatternIndex(newOptions(opts...))}
+	seen := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		p, err := Compile(pattern, opts...)
+		if err != nil {
+			return nil, err
+		}
+		s.add(p, len(s.patterns))
+		s.patterns = append(s.patterns, p)
+	}
+	return s, nil
+}
+
+func (s *PatternSet) Len() int {
+	return len(s.patterns)
+}
+
+// Patterns returns the patterns of the set in the order they were given.
+func (s *PatternSet) Patterns() []string {
+	patterns := make([]string, len(s.patterns))
+	for k := range s.patterns {
+		patterns[k] = s.patterns[k].pattern
+	}
+	return patterns
+}
+
+// Match reports whether any pattern of the set matches path.
+func (s *PatternSet) Match(path string) bool {
+	for _, id := range s.candidates(path) {
+		if s.patterns[id].Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns every pattern of the set that matches path, in the order
+// the patterns were given.
+func (s *PatternSet) Matches(path string) []string {
+	var matches []string
+	for _, id := range s.candidates(path) {
+		if s.patterns[id].Match(path) {
+			matches = append(matches, s.patterns[id].pattern)
+		}
+	}
+	return matches
+}
+
+//endregion
+
+//region patternIndex
+
+// patternIndex is the segment trie behind PatternSet and PatternMap. It maps
+// paths to the ids of the patterns that may match them.
+type patternIndex struct {
+	options
+	absolute *patternNode
+	relative *patternNode
+}
+
+func newPatternIndex(o options) patternIndex {
+	return patternIndex{
+		options:  o,
+		absolute: &patternNode{},
+		relative: &patternNode{},
+	}
+}
+
+func (s *patternIndex) add(p *Pattern, id int) {
+	s.root(p.pattern).add(p, id, s.caseSensitive)
+}
+
+func (s *patternIndex) remove(p *Pattern, id int) {
+	s.root(p.pattern).remove(p, id, s.caseSensitive)
+}
+
+func (s *patternIndex) root(path string) *patternNode {
+	if strings.HasPrefix(path, s.pathSeparator) {
+		return s.absolute
+	}
+	return s.relative
+}
+
+// candidates returns, in ascending order, the ids of the patterns whose
+// segments can line up with the segments of path. It may return patterns that
+// do not match, e.g. because of a trailing separator, but never misses one
+// that does.
+func (s *patternIndex) candidates(path string) []int {
+	var buf [PATH_SEGMENTS_BUFFER]string
+	pathDirs := pkg.AppendTokens(buf[:0], path, s.pathSeparator, s.trimTokens, true)
+	current := s.root(path).reach(nil)
+	var next []*patternNode
+	for _, pathDir := range pathDirs {
+		key := segmentKey(pathDir, s.caseSensitive)
+		next = next[:0]
+		for _, n := range current {
+			if n.doubleWildcard {
+				next = n.reach(next)
+			}
+			if child, ok := n.literals[key]; ok {
+				next = child.reach(next)
+			}
+			for _, child := range n.wildcards {
+				if child.matcher.matchStrings(pathDir, nil) {
+					next = child.reach(next)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		current, next = next, current
+	}
+	if strings.HasSuffix(path, s.pathSeparator) {
+		// "/a/*" matches "/a/" although the path has no segment left for "*"
+		for _, n := range current[:len(current):len(current)] {
+			for _, child := range n.wildcards {
+				if child.segment == "*" {
+					current = child.reach(current)
+				}
+			}
+		}
+	}
+	var ids []int
+	for _, n := range current {
+		ids = append(ids, n.ids...)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+//endregion
+
+//region patternNode
+
+type patternNode struct {
+	// literals holds the children for segments without wildcards, keyed by
+	// segmentKey.
+	literals map[string]*patternNode
+	// wildcards holds the children for single segments with wildcards or
+	// variables, each with the matcher of its segment.
+	wildcards []*patternNode
+	// double is the child for a "**" segment.
+	double         *patternNode
+	segment        string
+	matcher        *AntPathStringMatcher
+	doubleWildcard bool
+	// ids are the patterns that end at this node.
+	ids []int
+}
+
+func (n *patternNode) add(p *Pattern, id int, caseSensitive bool) {
+	n = n.walk(p, caseSensitive, true)
+	n.ids = append(n.ids, id)
+}
+
+// remove drops id from the node p ends at. Emptied nodes are kept; they only
+// cost a lookup until the index is rebuilt.
+func (n *patternNode) remove(p *Pattern, id int, caseSensitive bool) {
+	if n = n.walk(p, caseSensitive, false); n == nil {
+		return
+	}
+	for k := range n.ids {
+		if n.ids[k] == id {
+			n.ids = append(n.ids[:k], n.ids[k+1:]...)
+			return
+		}
+	}
+}
+
+// walk follows the segments of p from n and returns the node they end at,
+// creating the missing nodes if create is set and returning nil otherwise.
+func (n *patternNode) walk(p *Pattern, caseSensitive, create bool) *patternNode {
+	for k, segment := range p.segments {
+		if n == nil {
+			return nil
+		}
+		switch {
+		case segment == "**":
+			if n.double == nil && create {
+				n.double = &patternNode{segment: segment, doubleWildcard: true}
+			}
+			n = n.double
+		case p.matchers[k].exactMatch:
+			key := segmentKey(segment, caseSensitive)
+			child, ok := n.literals[key]
+			if !ok && create {
+				if n.literals == nil {
+					n.literals = make(map[string]*patternNode)
+				}
+				child = &patternNode{segment: segment}
+				n.literals[key] = child
+			}
+			n = child
+		default:
+			var child *patternNode
+			for _, wildcard := range n.wildcards {
+				if wildcard.segment == segment {
+					child = wildcard
+					break
+				}
+			}
+			if child == nil && create {
+				child = &patternNode{segment: segment, matcher: p.matchers[k]}
+				n.wildcards = append(n.wildcards, child)
+			}
+			n = child
+		}
+	}
+	return n
+}
+
+// reach appends n to nodes along with the "**" nodes that follow it, since
+// those match zero segments, skipping the ones nodes already holds.
+func (n *patternNode) reach(nodes []*patternNode) []*patternNode {
+	for ; n != nil; n = n.double {
+		for _, node := range nodes {
+			if node == n {
+				return nodes
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// segmentKey returns s as is when matching is case-sensitive, and otherwise a
+// key shared by every string strings.EqualFold considers equal to s.
+func segmentKey(s string, caseSensitive bool) string {
+	if caseSensitive {
+		return s
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= utf8.RuneSelf || 'A' <= c && c <= 'Z' {
+			return foldKey(s)
+		}
+	}
+	return s
+}
+
+func foldKey(s string) string {
+	var builder strings.Builder
+	builder.Grow(len(s))
+	for _, r := range s {
+		// the smallest rune of the case folding orbit, lower case for ASCII
+		// so that lower case keys need no copy
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		if 'A' <= folded && folded <= 'Z' {
+			folded += 'a' - 'A'
+		}
+		builder.WriteRune(folded)
+	}
+	return builder.String()
+}
+
+//endregion
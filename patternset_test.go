@@ -0,0 +1,114 @@
+package antpathmatcher
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+// @Author :George
+// @File: patternset_test
+// @Version: 1.0.0
+// @Date 2026/10/18 17:30
+
+var patternSetPatterns = []string{"test", "/test", "https://example.org", "/test.jpg", "t?st", "??st", "tes?",
+	"te??", "?es?", "*", "test*", "test/*", "*test*", "*test", "*.*", "test*aaa", "/?", "/?/a", "/a/?", "/??/a",
+	"/a/??", "/**", "/*/**", "/**/*", "/bla/**/bla", "/**/test", "/bla/**/**/bla", "/bla*bla/test", "/*bla/test",
+	"/????", "/**/*bla", "/*bla*/**/bla/**", "/*bla*/**/bla/*", "*bla*/**/bla/**", "*bla*/**/bla/*", "/x/x/**/bla",
+	"/foo/bar/**", "", "/{bla}.*", "/{bla}", "/{var:.*}", "/*", "/*/foo", "/foo/*/bar/**", "/**/foo/**",
+	"/hotels/{hotel}", "/hotels/{hotel:\\d+}/**", "/HOTELS/*", "/test/", "/*/"}
+
+var patternSetPaths = []string{"test", "/test", "https://example.org", "test.jpg", "tes", "testt", "tsst", "testTest",
+	"test/Test", "test/t", "test/", "AnothertestTest", "Anothertest", "test.", "test.test", "test.test.test",
+	"testblaaaa", "tst", "tsttest", "tsttst", "testblaaab", "/a", "/a/a", "/a/b", "/aa/a", "/a/bb", "/testing/testing",
+	"/bla/testing/testing/bla", "/bla/testing/testing/bla/bla", "/bla/bla/test", "/bla/bla/bla/bla/bla/bla",
+	"/blaXXXbla/test", "/XXXbla/test", "/blaXXXbl/test", "XXXblab/test", "XXXbl/test", "/bala/bla",
+	"/bla/bla/bla/bbb", "/XXXblaXXXX/testing/testing/bla/testing/testing/", "/XXXblaXXXX/testing/testing/bla/testing",
+	"/XXXblaXXXX/testing/testing/bla/testing/testing", "/XXXblaXXXX/testing/testing/bla/testing/testing.jpg",
+	"XXXblaXXXX/testing/testing/bla/testing/testing/", "XXXblaXXXX/testing/testing/bla/testing",
+	"XXXblaXXXX/testing/testing/bla/testing/testing", "/x/x/x/", "/foo/bar", "", "/testing.html", "//x\ny", "/x\ny",
+	"/", "/en/foo/", "/foo/x/bar", "/foo/x/bar/y/z", "/a/foo", "/hotels/1", "/hotels/1/rooms", "/hotels/x/rooms",
+	"/Hotels/1", "/test/", "/x/"}
+
+// patternSetExpected filters patterns with an AntPathMatcher.
+func patternSetExpected(pathMatcher *AntPathMatcher, patterns []string, path string) []string {
+	var expected []string
+	for _, pattern := range patterns {
+		if pathMatcher.Match(pattern, path) {
+			expected = append(expected, pattern)
+		}
+	}
+	return expected
+}
+
+func Test_patternSetMatchesAntPathMatcher(t *testing.T) {
+	e := assert.New(t)
+	optionSets := [][]Option{
+		nil,
+		{WithCaseSensitive(false)},
+		{WithTrimTokens(true)},
+		{WithPathSeparator(".")},
+	}
+	for _, opts := range optionSets {
+		var patterns []string
+		for _, pattern := range patternSetPatterns {
+			// e.g. "/{var:.*}" is split by the "." separator
+			if _, err := Compile(pattern, opts...); err == nil {
+				patterns = append(patterns, pattern)
+			}
+		}
+		set, err := NewPatternSet(patterns, opts...)
+		e.Nil(err)
+		pathMatcher := NewAntPathMatcherWithOptions(opts...)
+		for _, path := range patternSetPaths {
+			expected := patternSetExpected(pathMatcher, patterns, path)
+			e.Equal(set.Matches(path), expected, path)
+			e.Equal(set.Match(path), len(expected) > 0, path)
+		}
+	}
+}
+
+func Test_patternSet(t *testing.T) {
+	e := assert.New(t)
+	set, err := NewPatternSet([]string{"/hotels/**", "/hotels/{hotel}", "/hotels/*", "/hotels/{hotel}", "/motels/*"})
+	e.Nil(err)
+	e.Equal(set.Len(), 4)
+	e.Equal(set.Patterns(), []string{"/hotels/**", "/hotels/{hotel}", "/hotels/*", "/motels/*"})
+	e.Equal(set.Matches("/hotels/1"), []string{"/hotels/**", "/hotels/{hotel}", "/hotels/*"})
+	e.Equal(set.Matches("/hotels/"), []string{"/hotels/**", "/hotels/*"})
+	e.Equal(set.Matches("/hotels"), []string{"/hotels/**"})
+	e.Nil(set.Matches("/inns/1"))
+	e.False(set.Match("/inns/1"))
+
+	_, err = NewPatternSet([]string{"/hotels/*", "/hotels/{hotel:[0-9}"})
+	e.True(errors.Is(err, ErrInvalidPattern))
+
+	empty, err := NewPatternSet(nil)
+	e.Nil(err)
+	e.False(empty.Match("/hotels/1"))
+}
+
+func Test_patternSetCaseInsensitive(t *testing.T) {
+	e := assert.New(t)
+	set, err := NewPatternSet([]string{"/ΣΟΦΙΑ/*", "/straße/*", "/kelvin/*"}, WithCaseSensitive(false))
+	e.Nil(err)
+	e.True(set.Match("/σοφια/x"))
+	e.True(set.Match("/ςοφια/x"))
+	e.True(set.Match("/STRAßE/x"))
+	e.True(set.Match("/Kelvin/x"))
+	e.False(set.Match("/strasse/x"))
+}
+
+func Test_patternSetManyPatterns(t *testing.T) {
+	e := assert.New(t)
+	patterns := make([]string, 1000)
+	for k := range patterns {
+		patterns[k] = "/api/v" + strconv.Itoa(k%3) + "/resource" + strconv.Itoa(k) + "/{id}"
+	}
+	patterns = append(patterns, "/api/**")
+	set, err := NewPatternSet(patterns)
+	e.Nil(err)
+	e.Equal(set.Matches("/api/v1/resource700/42"), []string{"/api/v1/resource700/{id}", "/api/**"})
+	e.Equal(set.Matches("/api/v2/resource700/42"), []string{"/api/**"})
+}
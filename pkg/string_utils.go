@@ -3,6 +3,7 @@ package pkg
 import (
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // @Author :George
@@ -11,23 +12,59 @@ import (
 // @Date 2023/10/10 17:12
 
 func TokenizeToStringArray(str, delimiters string, trimTokens, ignoreEmptyTokens bool) []string {
+	return AppendTokens(make([]string, 0), str, delimiters, trimTokens, ignoreEmptyTokens)
+}
+
+// AppendTokens appends the tokens TokenizeToStringArray would return to dst.
+// The tokens are substrings of str, so nothing is allocated as long as dst
+// has enough capacity.
+func AppendTokens(dst []string, str, delimiters string, trimTokens, ignoreEmptyTokens bool) []string {
 	if strings.TrimSpace(str) == "" {
-		return []string{}
-	} else {
-		l := strings.FieldsFunc(str, func(a rune) bool {
-			return strings.Contains(delimiters, string(a))
-		})
-		tokens := make([]string, 0)
-		for k := range l {
-			if trimTokens {
-				l[k] = strings.TrimSpace(l[k])
+		return dst
+	}
+	if len(delimiters) == 1 && delimiters[0] < utf8.RuneSelf {
+		for len(str) > 0 {
+			i := strings.IndexByte(str, delimiters[0])
+			if i < 0 {
+				return appendToken(dst, str, trimTokens, ignoreEmptyTokens)
 			}
-			if !ignoreEmptyTokens || l[k] != "" {
-				tokens = append(tokens, l[k])
+			if i > 0 {
+				dst = appendToken(dst, str[:i], trimTokens, ignoreEmptyTokens)
+			}
+			str = str[i+1:]
+		}
+		return dst
+	}
+	start := -1
+	for i := 0; i < len(str); {
+		r, width := rune(str[i]), 1
+		if r >= utf8.RuneSelf {
+			r, width = utf8.DecodeRuneInString(str[i:])
+		}
+		if strings.ContainsRune(delimiters, r) {
+			if start >= 0 {
+				dst = appendToken(dst, str[start:i], trimTokens, ignoreEmptyTokens)
+				start = -1
 			}
+		} else if start < 0 {
+			start = i
 		}
-		return tokens
+		i += width
+	}
+	if start >= 0 {
+		dst = appendToken(dst, str[start:], trimTokens, ignoreEmptyTokens)
+	}
+	return dst
+}
+
+func appendToken(dst []string, token string, trimTokens, ignoreEmptyTokens bool) []string {
+	if trimTokens {
+		token = strings.TrimSpace(token)
+	}
+	if !ignoreEmptyTokens || token != "" {
+		dst = append(dst, token)
 	}
+	return dst
 }
 
 func HasText(str string) bool {
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// @Author :George
+// @File: string_utils_test
+// @Version: 1.0.0
+// @Date 2026/10/18 15:10
+
+func Test_tokenizeToStringArray(t *testing.T) {
+	e := assert.New(t)
+	e.Equal(TokenizeToStringArray("/a//b/", "/", false, true), []string{"a", "b"})
+	e.Equal(TokenizeToStringArray("a.b/c", "./", false, true), []string{"a", "b", "c"})
+	e.Equal(TokenizeToStringArray("/ a / b ", "/", true, true), []string{"a", "b"})
+	e.Equal(TokenizeToStringArray("/ a / /b", "/", true, false), []string{"a", "", "b"})
+	e.Equal(TokenizeToStringArray("/ a /", "/", false, true), []string{" a "})
+	e.Equal(TokenizeToStringArray("  ", "/", false, true), []string{})
+	e.Equal(TokenizeToStringArray("a→b→", "→", false, true), []string{"a", "b"})
+}
+
+func Test_appendTokensAllocations(t *testing.T) {
+	e := assert.New(t)
+	var buf [8]string
+	allocs := testing.AllocsPerRun(100, func() {
+		AppendTokens(buf[:0], "/docs/cvs/commit.html", "/", false, true)
+	})
+	e.Equal(allocs, 0.0)
+	e.Equal(AppendTokens(buf[:0], "/docs/cvs/commit.html", "/", false, true), []string{"docs", "cvs", "commit.html"})
+}
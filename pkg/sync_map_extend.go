@@ -1,21 +1,86 @@
 package pkg
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // @Author :George
 // @File: sync_map_extend
 // @Version: 1.0.0
 // @Date 2023/10/10 17:01
 
+// MySyncMap is a sync.Map that keeps track of its size, so Len is O(1)
+// instead of a Range over every entry. It has the methods of sync.Map; each
+// one that adds or removes an entry updates the counter. The methods that may
+// remove an entry, and Store, are serialized so that the counter stays exact;
+// Load, LoadOrStore and Range never wait.
 type MySyncMap struct {
-	sync.Map
+	length int64
+	mu     sync.Mutex
+	m      sync.Map
+}
+
+func (m *MySyncMap) Load(key any) (any, bool) {
+	return m.m.Load(key)
+}
+
+func (m *MySyncMap) Store(key, value any) {
+	m.Swap(key, value)
+}
+
+// LoadOrStore only ever adds an entry, which cannot race with the removals.
+func (m *MySyncMap) LoadOrStore(key, value any) (actual any, loaded bool) {
+	actual, loaded = m.m.LoadOrStore(key, value)
+	if !loaded {
+		atomic.AddInt64(&m.length, 1)
+	}
+	return actual, loaded
+}
+
+func (m *MySyncMap) LoadAndDelete(key any) (value any, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, loaded = m.m.LoadAndDelete(key)
+	if loaded {
+		atomic.AddInt64(&m.length, -1)
+	}
+	return value, loaded
+}
+
+func (m *MySyncMap) Delete(key any) {
+	m.LoadAndDelete(key)
+}
+
+func (m *MySyncMap) Swap(key, value any) (previous any, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	previous, loaded = m.m.Swap(key, value)
+	if !loaded {
+		atomic.AddInt64(&m.length, 1)
+	}
+	return previous, loaded
+}
+
+// CompareAndSwap only replaces an existing entry, so it leaves the size as is.
+func (m *MySyncMap) CompareAndSwap(key, old, new any) bool {
+	return m.m.CompareAndSwap(key, old, new)
+}
+
+func (m *MySyncMap) CompareAndDelete(key, old any) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	deleted := m.m.CompareAndDelete(key, old)
+	if deleted {
+		atomic.AddInt64(&m.length, -1)
+	}
+	return deleted
+}
+
+func (m *MySyncMap) Range(f func(key, value any) bool) {
+	m.m.Range(f)
 }
 
 func (m *MySyncMap) Len() int {
-	leng := 0
-	m.Range(func(k, v any) bool {
-		leng++
-		return true
-	})
-	return leng
+	return int(atomic.LoadInt64(&m.length))
 }
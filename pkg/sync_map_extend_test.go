@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// @Author :George
+// @File: sync_map_extend_test
+// @Version: 1.0.0
+// @Date 2026/10/18 14:20
+
+func Test_mySyncMapLen(t *testing.T) {
+	e := assert.New(t)
+	m := MySyncMap{}
+	e.Equal(m.Len(), 0)
+
+	actual, loaded := m.LoadOrStore("a", 1)
+	e.False(loaded)
+	e.Equal(actual, 1)
+	actual, loaded = m.LoadOrStore("a", 2)
+	e.True(loaded)
+	e.Equal(actual, 1)
+	e.Equal(m.Len(), 1)
+
+	m.Delete("a")
+	m.Delete("a")
+	e.Equal(m.Len(), 0)
+	_, ok := m.Load("a")
+	e.False(ok)
+}
+
+func Test_mySyncMapMutators(t *testing.T) {
+	e := assert.New(t)
+	m := MySyncMap{}
+	m.Store("a", 1)
+	m.Store("a", 2)
+	e.Equal(m.Len(), 1)
+
+	previous, loaded := m.Swap("a", 3)
+	e.True(loaded)
+	e.Equal(previous, 2)
+	_, loaded = m.Swap("b", 1)
+	e.False(loaded)
+	e.Equal(m.Len(), 2)
+
+	e.False(m.CompareAndSwap("a", 2, 4))
+	e.True(m.CompareAndSwap("a", 3, 4))
+	e.False(m.CompareAndSwap("c", nil, 1))
+	e.Equal(m.Len(), 2)
+
+	e.False(m.CompareAndDelete("a", 3))
+	e.True(m.CompareAndDelete("a", 4))
+	e.False(m.CompareAndDelete("a", 4))
+	e.Equal(m.Len(), 1)
+
+	value, loaded := m.LoadAndDelete("b")
+	e.True(loaded)
+	e.Equal(value, 1)
+	_, loaded = m.LoadAndDelete("b")
+	e.False(loaded)
+	e.Equal(m.Len(), 0)
+}
+
+func Test_mySyncMapConcurrentLen(t *testing.T) {
+	m := MySyncMap{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.LoadOrStore(strconv.Itoa(j), j)
+				m.Store("store"+strconv.Itoa(j), j)
+				m.Delete("store" + strconv.Itoa(j))
+			}
+		}()
+	}
+	wg.Wait()
+	count := 0
+	m.Range(func(k, v any) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, m.Len(), 1000)
+	assert.Equal(t, count, 1000)
+}
@@ -0,0 +1,274 @@
+package requestmatcher
+
+import (
+	"fmt"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"net/http"
+	"strings"
+)
+
+// @Author :George
+// @File: requestmatcher
+// @Version: 1.0.0
+// @Date 2026/10/18 22:20
+
+// MATCH_ALL is the pattern matching every request, whatever its path.
+const MATCH_ALL = "/**"
+
+// RequestMatcher decides whether a request matches, like the RequestMatcher of
+// Spring Security.
+type RequestMatcher interface {
+	Matches(r *http.Request) bool
+	// Matcher also returns the URI template variables extracted from the request.
+	Matcher(r *http.Request) MatchResult
+}
+
+// MatchResult is the result of RequestMatcher.Matcher. Variables is never nil
+// for a match.
+type MatchResult struct {
+	Match     bool
+	Variables map[string]string
+}
+
+func match(variables map[string]string) MatchResult {
+	if variables == nil {
+		variables = make(map[string]string)
+	}
+	return MatchResult{Match: true, Variables: variables}
+}
+
+// AnyRequest matches every request.
+var AnyRequest RequestMatcher = anyRequestMatcher{}
+
+type anyRequestMatcher struct{}
+
+func (anyRequestMatcher) Matches(*http.Request) bool {
+	return true
+}
+
+func (anyRequestMatcher) Matcher(*http.Request) MatchResult {
+	return match(nil)
+}
+
+func (anyRequestMatcher) String() string {
+	return "any request"
+}
+
+//region AntPathRequestMatcher
+
+// AntPathRequestMatcher matches the path of a request against an Ant pattern
+// and, if it has one, its method.
+type AntPathRequestMatcher struct {
+	pattern *antpathmatcher.Pattern
+	method  string
+}
+
+// NewAntPathRequestMatcher returns a matcher of the requests with the given
+// method, or any method if it is empty, whose path matches pattern. The error
+// is the one antpathmatcher.Compile returns for an invalid pattern.
+func NewAntPathRequestMatcher(pattern, method string, caseSensitive bool) (*AntPathRequestMatcher, error) {
+	if pattern == "**" {
+		pattern = MATCH_ALL
+	}
+	p, err := antpathmatcher.Compile(pattern, antpathmatcher.WithCaseSensitive(caseSensitive))
+	if err != nil {
+		return nil, err
+	}
+	return &AntPathRequestMatcher{pattern: p, method: method}, nil
+}
+
+func MustAntPathRequestMatcher(pattern, method string, caseSensitive bool) *AntPathRequestMatcher {
+	m, err := NewAntPathRequestMatcher(pattern, method, caseSensitive)
+	if err != nil {
+		panic(err.Error())
+	}
+	return m
+}
+
+func (m *AntPathRequestMatcher) Matches(r *http.Request) bool {
+	return m.matchesMethod(r) && (m.pattern.String() == MATCH_ALL || m.pattern.Match(r.URL.Path))
+}
+
+func (m *AntPathRequestMatcher) Matcher(r *http.Request) MatchResult {
+	if !m.matchesMethod(r) {
+		return MatchResult{}
+	}
+	if m.pattern.String() == MATCH_ALL {
+		return match(nil)
+	}
+	variables, err := m.pattern.ExtractUriTemplateVariables(r.URL.Path)
+	if err != nil {
+		return MatchResult{}
+	}
+	return match(variables)
+}
+
+func (m *AntPathRequestMatcher) matchesMethod(r *http.Request) bool {
+	if m.method == "" {
+		return true
+	}
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return method == m.method
+}
+
+func (m *AntPathRequestMatcher) Pattern() string {
+	return m.pattern.String()
+}
+
+func (m *AntPathRequestMatcher) String() string {
+	if m.method == "" {
+		return "Ant [pattern='" + m.pattern.String() + "']"
+	}
+	return "Ant [pattern='" + m.pattern.String() + "', " + m.method + "]"
+}
+
+//endregion
+
+//region RequestHeaderRequestMatcher
+
+// RequestHeaderRequestMatcher matches the requests having a header, with a
+// given value if it is not empty.
+type RequestHeaderRequestMatcher struct {
+	name  string
+	value string
+}
+
+func NewRequestHeaderRequestMatcher(name, value string) *RequestHeaderRequestMatcher {
+	return &RequestHeaderRequestMatcher{name: name, value: value}
+}
+
+func (m *RequestHeaderRequestMatcher) Matches(r *http.Request) bool {
+	values := r.Header.Values(m.name)
+	if m.value == "" {
+		return len(values) > 0
+	}
+	for _, value := range values {
+		if value == m.value {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *RequestHeaderRequestMatcher) Matcher(r *http.Request) MatchResult {
+	if m.Matches(r) {
+		return match(nil)
+	}
+	return MatchResult{}
+}
+
+func (m *RequestHeaderRequestMatcher) String() string {
+	return "RequestHeaderRequestMatcher [expectedHeaderName=" + m.name + ", expectedHeaderValue=" + m.value + "]"
+}
+
+//endregion
+
+//region combinators
+
+type andRequestMatcher []RequestMatcher
+
+// And matches the requests all of matchers match, merging their variables.
+// It panics without matchers.
+func And(matchers ...RequestMatcher) RequestMatcher {
+	if len(matchers) == 0 {
+		panic("requestmatcher: And needs at least one matcher")
+	}
+	return andRequestMatcher(matchers)
+}
+
+func (m andRequestMatcher) Matches(r *http.Request) bool {
+	for _, matcher := range m {
+		if !matcher.Matches(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m andRequestMatcher) Matcher(r *http.Request) MatchResult {
+	variables := make(map[string]string)
+	for _, matcher := range m {
+		result := matcher.Matcher(r)
+		if !result.Match {
+			return MatchResult{}
+		}
+		for name, value := range result.Variables {
+			variables[name] = value
+		}
+	}
+	return match(variables)
+}
+
+func (m andRequestMatcher) String() string {
+	return "And " + formatMatchers(m)
+}
+
+type orRequestMatcher []RequestMatcher
+
+// Or matches the requests any of matchers matches, with the variables of the
+// first that does. It panics without matchers.
+func Or(matchers ...RequestMatcher) RequestMatcher {
+	if len(matchers) == 0 {
+		panic("requestmatcher: Or needs at least one matcher")
+	}
+	return orRequestMatcher(matchers)
+}
+
+func (m orRequestMatcher) Matches(r *http.Request) bool {
+	for _, matcher := range m {
+		if matcher.Matches(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m orRequestMatcher) Matcher(r *http.Request) MatchResult {
+	for _, matcher := range m {
+		if result := matcher.Matcher(r); result.Match {
+			return result
+		}
+	}
+	return MatchResult{}
+}
+
+func (m orRequestMatcher) String() string {
+	return "Or " + formatMatchers(m)
+}
+
+type notRequestMatcher struct {
+	matcher RequestMatcher
+}
+
+// Not matches the requests matcher does not match, without variables.
+func Not(matcher RequestMatcher) RequestMatcher {
+	return notRequestMatcher{matcher: matcher}
+}
+
+func (m notRequestMatcher) Matches(r *http.Request) bool {
+	return !m.matcher.Matches(r)
+}
+
+func (m notRequestMatcher) Matcher(r *http.Request) MatchResult {
+	if m.Matches(r) {
+		return match(nil)
+	}
+	return MatchResult{}
+}
+
+func (m notRequestMatcher) String() string {
+	return "Not [" + fmt.Sprint(m.matcher) + "]"
+}
+
+func formatMatchers(matchers []RequestMatcher) string {
+	formatted := make([]string, len(matchers))
+	for k, matcher := range matchers {
+		formatted[k] = fmt.Sprint(matcher)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+//endregion
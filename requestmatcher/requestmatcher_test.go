@@ -0,0 +1,105 @@
+package requestmatcher
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// @Author :George
+// @File: requestmatcher_test
+// @Version: 1.0.0
+// @Date 2026/10/18 22:40
+
+func Test_antPathRequestMatcher(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		pattern       string
+		method        string
+		caseSensitive bool
+		request       *http.Request
+		matches       bool
+		variables     map[string]string
+	}{
+		{"/api/**", "", true, httptest.NewRequest(http.MethodPost, "/api/users", nil), true, map[string]string{}},
+		{"/api/**", http.MethodGet, true, httptest.NewRequest(http.MethodPost, "/api/users", nil), false, nil},
+		{"/api/**", http.MethodGet, true, httptest.NewRequest(http.MethodGet, "/api", nil), true, map[string]string{}},
+		{"/users/{id}", http.MethodGet, true, httptest.NewRequest(http.MethodGet, "/users/42", nil), true, map[string]string{"id": "42"}},
+		{"/users/{id}", "", true, httptest.NewRequest(http.MethodGet, "/USERS/42", nil), false, nil},
+		{"/users/{id}", "", false, httptest.NewRequest(http.MethodGet, "/USERS/42", nil), true, map[string]string{"id": "42"}},
+		{"/users/{id}", "", true, httptest.NewRequest(http.MethodGet, "/users/42?x=/y", nil), true, map[string]string{"id": "42"}},
+		{"**", http.MethodDelete, true, httptest.NewRequest(http.MethodDelete, "/anything/at/all", nil), true, map[string]string{}},
+		{"/**", "", true, httptest.NewRequest(http.MethodGet, "/", nil), true, map[string]string{}},
+		{"/users/{id}", http.MethodGet, true, &http.Request{URL: httptest.NewRequest(http.MethodGet, "/users/1", nil).URL}, true, map[string]string{"id": "1"}},
+	}
+	for _, test := range tests {
+		m, err := NewAntPathRequestMatcher(test.pattern, test.method, test.caseSensitive)
+		e.NoError(err)
+		e.Equal(m.Matches(test.request), test.matches, "%v %s", m, test.request.URL)
+		result := m.Matcher(test.request)
+		e.Equal(result, MatchResult{Match: test.matches, Variables: test.variables}, "%v %s", m, test.request.URL)
+	}
+}
+
+func Test_newAntPathRequestMatcherError(t *testing.T) {
+	e := assert.New(t)
+	_, err := NewAntPathRequestMatcher("/users/{id:(a)}", "", true)
+	e.True(errors.Is(err, antpathmatcher.ErrCapturingGroup))
+	e.Panics(func() { MustAntPathRequestMatcher("/users/{id:(a)}", "", true) })
+	e.Equal(MustAntPathRequestMatcher("/users/{id}", "", true).Pattern(), "/users/{id}")
+}
+
+func Test_requestHeaderRequestMatcher(t *testing.T) {
+	e := assert.New(t)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("X-Requested-With", "XMLHttpRequest")
+	e.True(NewRequestHeaderRequestMatcher("X-Requested-With", "").Matches(r))
+	e.True(NewRequestHeaderRequestMatcher("x-requested-with", "XMLHttpRequest").Matches(r))
+	e.False(NewRequestHeaderRequestMatcher("X-Requested-With", "fetch").Matches(r))
+	e.False(NewRequestHeaderRequestMatcher("Authorization", "").Matches(r))
+	e.Equal(NewRequestHeaderRequestMatcher("X-Requested-With", "").Matcher(r), MatchResult{Match: true, Variables: map[string]string{}})
+}
+
+func Test_combinators(t *testing.T) {
+	e := assert.New(t)
+	users := MustAntPathRequestMatcher("/users/{id}/**", "", true)
+	posts := MustAntPathRequestMatcher("/**/posts/{post}", http.MethodGet, true)
+	ajax := NewRequestHeaderRequestMatcher("X-Requested-With", "XMLHttpRequest")
+	r := httptest.NewRequest(http.MethodGet, "/users/42/posts/7", nil)
+
+	and := And(users, posts)
+	e.True(and.Matches(r))
+	e.Equal(and.Matcher(r), MatchResult{Match: true, Variables: map[string]string{"id": "42", "post": "7"}})
+	e.False(And(users, ajax).Matches(r))
+	e.Equal(And(users, ajax).Matcher(r), MatchResult{})
+
+	or := Or(ajax, posts, users)
+	e.True(or.Matches(r))
+	e.Equal(or.Matcher(r), MatchResult{Match: true, Variables: map[string]string{"post": "7"}})
+	e.False(Or(ajax).Matches(r))
+	e.Equal(Or(ajax).Matcher(r), MatchResult{})
+
+	e.False(Not(users).Matches(r))
+	e.Equal(Not(users).Matcher(r), MatchResult{})
+	e.True(Not(ajax).Matches(r))
+	e.Equal(Not(ajax).Matcher(r), MatchResult{Match: true, Variables: map[string]string{}})
+
+	e.True(And(AnyRequest, Not(ajax)).Matches(r))
+	e.Panics(func() { And() })
+	e.Panics(func() { Or() })
+}
+
+func Test_requestMatcherString(t *testing.T) {
+	e := assert.New(t)
+	m := Or(
+		And(MustAntPathRequestMatcher("/api/**", http.MethodPost, true), NewRequestHeaderRequestMatcher("X-Token", "")),
+		Not(MustAntPathRequestMatcher("/public/**", "", true)),
+		AnyRequest,
+	)
+	e.Equal(m.(interface{ String() string }).String(), "Or [And [Ant [pattern='/api/**', POST], "+
+		"RequestHeaderRequestMatcher [expectedHeaderName=X-Token, expectedHeaderValue=]], "+
+		"Not [Ant [pattern='/public/**']], any request]")
+}
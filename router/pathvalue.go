@@ -0,0 +1,36 @@
+package router
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"net/http"
+)
+
+// @Author :George
+// @File: pathvalue
+// @Version: 1.0.0
+// @Date 2026/10/18 19:50
+
+// PathValues returns a middleware that matches the request path against
+// pattern and, when it matches, sets each URI template variable as a path
+// value of the request, so handlers can read it with r.PathValue. Requests
+// whose path does not match are passed on untouched.
+func PathValues(pattern string, opts ...antpathmatcher.Option) (func(http.Handler) http.Handler, error) {
+	p, err := antpathmatcher.Compile(pattern, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if vars, err := p.ExtractUriTemplateVariables(r.URL.Path); err == nil {
+				setPathValues(r, vars)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func setPathValues(r *http.Request, vars map[string]string) {
+	for name, value := range vars {
+		r.SetPathValue(name, value)
+	}
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+// @Author :George
+// @File: pathvalue_test
+// @Version: 1.0.0
+// @Date 2026/10/18 20:00
+
+func pathValueHandler(names ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, name := range names {
+			_, _ = w.Write([]byte(name + "=" + r.PathValue(name) + ";"))
+		}
+	}
+}
+
+func Test_pathValues(t *testing.T) {
+	e := assert.New(t)
+	middleware, err := PathValues("/hotels/{hotel}/bookings/{booking:\\d+}")
+	e.Nil(err)
+	handler := middleware(pathValueHandler("hotel", "booking"))
+
+	e.Equal(serve(handler, http.MethodGet, "/hotels/ritz/bookings/42").Body.String(), "hotel=ritz;booking=42;")
+	// not a match: the request goes through without path values
+	e.Equal(serve(handler, http.MethodGet, "/hotels/ritz/bookings/x").Body.String(), "hotel=;booking=;")
+
+	_, err = PathValues("/hotels/{hotel:[0-9}")
+	e.True(errors.Is(err, antpathmatcher.ErrInvalidPattern))
+}
+
+func Test_pathValuesWithServeMux(t *testing.T) {
+	e := assert.New(t)
+	middleware, err := PathValues("/files/{name}.{ext}")
+	e.Nil(err)
+	mux := http.NewServeMux()
+	mux.Handle("GET /files/", middleware(pathValueHandler("name", "ext")))
+	e.Equal(serve(mux, http.MethodGet, "/files/report.pdf").Body.String(), "name=report;ext=pdf;")
+}
+
+func Test_routerSetsPathValues(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.Handle(http.MethodGet, "/hotels/{hotel}", pathValueHandler("hotel")))
+	e.Equal(serve(r, http.MethodGet, "/hotels/ritz").Body.String(), "hotel=ritz;")
+}
@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// @Author :George
+// @File: router
+// @Version: 1.0.0
+// @Date 2026/10/18 19:00
+
+var ErrDuplicateRoute = errors.New("router: duplicate route")
+
+// ANY_METHOD registers a handler for every method a pattern has no other
+// handler for.
+const ANY_METHOD = ""
+
+type varsKey struct{}
+
+//region Router
+
+// Router is an http.Handler dispatching requests by method and Ant pattern.
+// The most specific pattern, as ordered by AntPatternComparator, with a
+// handler for the request method wins; its URI template variables are
+// available to the handler through Vars and Var as well as r.PathValue. A HEAD
+// request falls back to the GET handler, like with http.ServeMux. A path that
+// matches patterns but none for its method gets a 405 with an Allow header,
+// any other a 404.
+type Router struct {
+	// mu only serializes Handle, which reads a route before replacing it.
+	// ServeHTTP goes through index, which locks itself, and a route is never
+	// modified once it is in index.
+	mu     sync.Mutex
+	routes map[string]*route
+	index  *antpathmatcher.PatternMap[*route]
+	// NotFound handles requests no pattern matches, http.NotFound if nil.
+	NotFound http.Handler
+}
+
+type route struct {
+	handlers map[string]http.Handler
+}
+
+func New(opts ...antpathmatcher.Option) *Router {
+	return &Router{
+		routes: make(map[string]*route),
+		index:  antpathmatcher.NewPatternMap[*route](opts...),
+	}
+}
+
+// Handle registers handler for method and pattern. ANY_METHOD stands for the
+// methods pattern has no handler of its own for. Registering a method and
+// pattern twice returns ErrDuplicateRoute; an invalid pattern returns the
+// error of antpathmatcher.Compile.
+func (r *Router) Handle(method, pattern string, handler http.Handler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	handlers := make(map[string]http.Handler)
+	if rt, ok := r.routes[pattern]; ok {
+		if _, ok := rt.handlers[method]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateRoute, strings.TrimSpace(method+" "+pattern))
+		}
+		for m, h := range rt.handlers {
+			handlers[m] = h
+		}
+	}
+	handlers[method] = handler
+	rt := &route{handlers: handlers}
+	if err := r.index.Put(pattern, rt); err != nil {
+		return err
+	}
+	r.routes[pattern] = rt
+	return nil
+}
+
+func (r *Router) HandleFunc(method, pattern string, handler func(http.ResponseWriter, *http.Request)) error {
+	return r.Handle(method, pattern, http.HandlerFunc(handler))
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler, vars, allow := r.lookup(req.Method, req.URL.Path)
+	if handler != nil {
+		req = req.WithContext(context.WithValue(req.Context(), varsKey{}, vars))
+		setPathValues(req, vars)
+		handler.ServeHTTP(w, req)
+	} else if len(allow) > 0 {
+		w.Header().Set("Allow", strings.Join(allow, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	} else if r.NotFound != nil {
+		r.NotFound.ServeHTTP(w, req)
+	} else {
+		http.NotFound(w, req)
+	}
+}
+
+// lookup returns the handler for method and path with its variables, or the
+// sorted methods the patterns matching path do have handlers for.
+func (r *Router) lookup(method, path string) (http.Handler, map[string]string, []string) {
+	var allow []string
+	for _, match := range r.index.GetAll(path) {
+		handlers := match.Value.handlers
+		if handler, ok := handlers[method]; ok {
+			return handler, match.Variables, nil
+		}
+		if handler, ok := handlers[http.MethodGet]; ok && method == http.MethodHead {
+			return handler, match.Variables, nil
+		}
+		if handler, ok := handlers[ANY_METHOD]; ok {
+			return handler, match.Variables, nil
+		}
+		for m := range handlers {
+			allow = append(allow, m)
+			if m == http.MethodGet {
+				allow = append(allow, http.MethodHead)
+			}
+		}
+	}
+	sort.Strings(allow)
+	unique := allow[:0]
+	for k := range allow {
+		if k == 0 || allow[k] != allow[k-1] {
+			unique = append(unique, allow[k])
+		}
+	}
+	return nil, nil, unique
+}
+
+//endregion
+
+// Vars returns the URI template variables of the pattern that matched r, nil
+// if r was not dispatched by a Router.
+func Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(varsKey{}).(map[string]string)
+	return vars
+}
+
+// Var returns the URI template variable name of the pattern that matched r.
+func Var(r *http.Request, name string) string {
+	return Vars(r)[name]
+}
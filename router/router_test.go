@@ -0,0 +1,138 @@
+package router
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// @Author :George
+// @File: router_test
+// @Version: 1.0.0
+// @Date 2026/10/18 19:30
+
+// respond writes name followed by the variables of the matched pattern.
+func respond(name string, variables ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(name))
+		for _, variable := range variables {
+			_, _ = w.Write([]byte(" " + variable + "=" + Var(r, variable)))
+		}
+	}
+}
+
+func serve(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func Test_router(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.Handle(http.MethodGet, "/hotels/{hotel}", respond("hotel", "hotel")))
+	e.Nil(r.Handle(http.MethodPut, "/hotels/{hotel}", respond("put hotel", "hotel")))
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/new", respond("new hotel")))
+	e.Nil(r.HandleFunc(http.MethodPost, "/hotels/new", respond("create hotel")))
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/{hotel}/bookings/{booking}", respond("booking", "hotel", "booking")))
+	e.Nil(r.HandleFunc(http.MethodGet, "/**", respond("fallback")))
+
+	w := serve(r, http.MethodGet, "/hotels/new")
+	e.Equal(w.Code, http.StatusOK)
+	e.Equal(w.Body.String(), "new hotel")
+	e.Equal(serve(r, http.MethodGet, "/hotels/1").Body.String(), "hotel hotel=1")
+	e.Equal(serve(r, http.MethodPut, "/hotels/1").Body.String(), "put hotel hotel=1")
+	e.Equal(serve(r, http.MethodGet, "/hotels/1/bookings/2").Body.String(), "booking hotel=1 booking=2")
+	e.Equal(serve(r, http.MethodGet, "/motels/1").Body.String(), "fallback")
+	// "/hotels/new" has no PUT, the less specific "/hotels/{hotel}" has
+	e.Equal(serve(r, http.MethodPut, "/hotels/new").Body.String(), "put hotel hotel=new")
+}
+
+func Test_routerMethodNotAllowed(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/{hotel}", respond("hotel")))
+	e.Nil(r.HandleFunc(http.MethodPut, "/hotels/{hotel}", respond("put hotel")))
+	e.Nil(r.HandleFunc(http.MethodPost, "/hotels/*", respond("post hotel")))
+	e.Nil(r.HandleFunc(http.MethodGet, "/motels/*", respond("motel")))
+
+	w := serve(r, http.MethodDelete, "/hotels/1")
+	e.Equal(w.Code, http.StatusMethodNotAllowed)
+	e.Equal(w.Header().Get("Allow"), "GET, HEAD, POST, PUT")
+
+	w = serve(r, http.MethodDelete, "/inns/1")
+	e.Equal(w.Code, http.StatusNotFound)
+	e.Equal(w.Header().Get("Allow"), "")
+
+	r.NotFound = respond("not found")
+	e.Equal(serve(r, http.MethodGet, "/inns/1").Body.String(), "not found")
+}
+
+func Test_routerAnyMethod(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.HandleFunc(ANY_METHOD, "/hotels/*", respond("any")))
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/*", respond("get")))
+	e.Equal(serve(r, http.MethodGet, "/hotels/1").Body.String(), "get")
+	e.Equal(serve(r, http.MethodDelete, "/hotels/1").Body.String(), "any")
+}
+
+func Test_routerHead(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/{hotel}", respond("hotel")))
+	e.Nil(r.HandleFunc(http.MethodHead, "/hotels/new", respond("head new hotel")))
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/new", respond("new hotel")))
+	e.Equal(serve(r, http.MethodHead, "/hotels/1").Code, http.StatusOK)
+	e.Equal(serve(r, http.MethodHead, "/hotels/1").Body.String(), "hotel")
+	e.Equal(serve(r, http.MethodHead, "/hotels/new").Body.String(), "head new hotel")
+}
+
+func Test_routerConcurrentHandle(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/*", respond("hotel")))
+	methods := []string{http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch}
+	wg := sync.WaitGroup{}
+	for _, method := range methods {
+		wg.Add(1)
+		go func(method string) {
+			defer wg.Done()
+			e.Nil(r.HandleFunc(method, "/hotels/*", respond(method)))
+			serve(r, http.MethodGet, "/hotels/1")
+		}(method)
+	}
+	wg.Wait()
+	for _, method := range methods {
+		e.Equal(serve(r, method, "/hotels/1").Body.String(), method)
+	}
+	e.Equal(serve(r, http.MethodGet, "/hotels/1").Body.String(), "hotel")
+}
+
+func Test_routerErrors(t *testing.T) {
+	e := assert.New(t)
+	r := New()
+	e.Nil(r.HandleFunc(http.MethodGet, "/hotels/*", respond("hotel")))
+	e.True(errors.Is(r.HandleFunc(http.MethodGet, "/hotels/*", respond("hotel")), ErrDuplicateRoute))
+	e.True(errors.Is(r.HandleFunc(http.MethodGet, "/hotels/{hotel:[0-9}", respond("hotel")), antpathmatcher.ErrInvalidPattern))
+	// the invalid pattern did not leave a route behind
+	e.Equal(serve(r, http.MethodGet, "/hotels/{hotel:[0-9}").Body.String(), "hotel")
+}
+
+func Test_routerOptions(t *testing.T) {
+	e := assert.New(t)
+	r := New(antpathmatcher.WithCaseSensitive(false))
+	e.Nil(r.HandleFunc(http.MethodGet, "/Hotels/{hotel}", respond("hotel", "hotel")))
+	e.Equal(serve(r, http.MethodGet, "/hotels/Ritz").Body.String(), "hotel hotel=Ritz")
+}
+
+func Test_varsOutsideRouter(t *testing.T) {
+	e := assert.New(t)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	e.Nil(Vars(req))
+	e.Equal(Var(req, "hotel"), "")
+}
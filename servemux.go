@@ -0,0 +1,196 @@
+package antpathmatcher
+
+import (
+	"fmt"
+	"github.com/georgeJobs/go-antpathmatcher/internal/naming"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// @Author :George
+// @File: servemux
+// @Version: 1.0.0
+// @Date 2026/10/18 20:20
+
+const (
+	REASON_RELATIVE_PATTERN        = "ServeMux patterns are absolute"
+	REASON_REGEX_VARIABLE          = "regular expressions are not supported"
+	REASON_SEGMENT_WILDCARD        = "wildcards within a segment are not supported"
+	REASON_SINGLE_CHAR_WILDCARD    = "single character wildcards are not supported"
+	REASON_INNER_DOUBLE_WILDCARD   = "multi-segment wildcards must be last"
+	REASON_INVALID_NAME            = "wildcard names must be Go identifiers"
+	REASON_HOST                    = "Ant patterns have no host"
+	REASON_EMPTY_SEGMENT           = "Ant patterns ignore empty segments"
+	REASON_ANT_WILDCARD_CHARACTER  = "the character is a wildcard in Ant patterns"
+	REASON_INVALID_SERVEMUX_SYNTAX = "invalid ServeMux syntax"
+)
+
+// Unconvertible is a construct of a pattern with no equivalent in the other
+// syntax.
+type Unconvertible struct {
+	Construct string
+	Reason    string
+}
+
+// ConversionError lists every construct that prevented a conversion.
+type ConversionError struct {
+	Pattern       string
+	Unconvertible []Unconvertible
+}
+
+func (e *ConversionError) Error() string {
+	constructs := make([]string, len(e.Unconvertible))
+	for k, u := range e.Unconvertible {
+		constructs[k] = strconv.Quote(u.Construct) + " (" + u.Reason + ")"
+	}
+	return fmt.Sprintf("antpathmatcher: cannot convert %q: %s", e.Pattern, strings.Join(constructs, ", "))
+}
+
+func (e *ConversionError) Unwrap() error {
+	return ErrUnconvertiblePattern
+}
+
+// ToServeMuxPattern converts an Ant pattern to an http.ServeMux pattern for
+// method, which may be empty to match every method. "{name}" is kept, "*"
+// becomes "{segN}" with N the index of the segment, a trailing "**" becomes
+// "{path...}" and a trailing separator "{$}". Note that "/files/**" also
+// matches "/files" while "/files/{path...}" does not.
+func ToServeMuxPattern(method, pattern string) (string, error) {
+	if errs := Validate(pattern); len(errs) > 0 {
+		return "", &errs[0]
+	}
+	conversionError := &ConversionError{Pattern: pattern}
+	unconvertible := func(construct, reason string) {
+		conversionError.Unconvertible = append(conversionError.Unconvertible, Unconvertible{construct, reason})
+	}
+	if !strings.HasPrefix(pattern, DEFAULT_PATH_SEPARATOR) {
+		unconvertible(pattern, REASON_RELATIVE_PATTERN)
+	}
+	segments := strings.FieldsFunc(pattern, func(r rune) bool { return r == '/' })
+	names := naming.Names{}
+	for _, segment := range segments {
+		if name, ok := wholeSegmentVariable(segment); ok {
+			names.Add(name)
+		}
+	}
+	builder := strings.Builder{}
+	if method != "" {
+		builder.WriteString(method + " ")
+	}
+	for k, segment := range segments {
+		builder.WriteString("/")
+		last := k == len(segments)-1 && !strings.HasSuffix(pattern, DEFAULT_PATH_SEPARATOR)
+		switch {
+		case segment == "**" && last:
+			builder.WriteString("{" + names.CatchAll() + "...}")
+		case segment == "**":
+			unconvertible(segment, REASON_INNER_DOUBLE_WILDCARD)
+		case segment == "*":
+			builder.WriteString("{" + names.Wildcard(k) + "}")
+		case !strings.ContainsAny(segment, "*?{}"):
+			builder.WriteString(segment)
+		case segment == "?":
+			unconvertible(segment, REASON_SINGLE_CHAR_WILDCARD)
+		case segment[0] != '{' || GLOB_PATTERN.FindString(segment) != segment:
+			unconvertible(segment, REASON_SEGMENT_WILDCARD)
+		default:
+			if name, ok := wholeSegmentVariable(segment); !ok {
+				unconvertible(segment, REASON_REGEX_VARIABLE)
+			} else if !isServeMuxName(name) {
+				unconvertible(segment, REASON_INVALID_NAME)
+			} else {
+				builder.WriteString(segment)
+			}
+		}
+	}
+	if strings.HasSuffix(pattern, DEFAULT_PATH_SEPARATOR) {
+		builder.WriteString("/{$}")
+	}
+	if len(conversionError.Unconvertible) > 0 {
+		return "", conversionError
+	}
+	return builder.String(), nil
+}
+
+// FromServeMuxPattern converts an http.ServeMux pattern to its method and an
+// Ant pattern. "{name}" is kept, "{name...}" becomes "**", losing its name,
+// "{$}" a trailing separator and a trailing "/" without "{$}" "/**".
+func FromServeMuxPattern(pattern string) (method, antPattern string, err error) {
+	conversionError := &ConversionError{Pattern: pattern}
+	unconvertible := func(construct, reason string) {
+		conversionError.Unconvertible = append(conversionError.Unconvertible, Unconvertible{construct, reason})
+	}
+	rest := pattern
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method, rest = pattern[:i], strings.TrimLeft(pattern[i+1:], " \t")
+	}
+	slash := strings.IndexByte(rest, '/')
+	if slash < 0 {
+		unconvertible(rest, REASON_INVALID_SERVEMUX_SYNTAX)
+		return "", "", conversionError
+	}
+	if slash > 0 {
+		unconvertible(rest[:slash], REASON_HOST)
+	}
+	segments := strings.Split(rest[slash+1:], "/")
+	builder := strings.Builder{}
+	for k, segment := range segments {
+		last := k == len(segments)-1
+		switch {
+		case segment == "" && last:
+			// "/files/" matches the whole subtree
+			builder.WriteString("/**")
+		case segment == "":
+			unconvertible("//", REASON_EMPTY_SEGMENT)
+		case segment == "{$}" && last:
+			builder.WriteString("/")
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}"):
+			if !last {
+				unconvertible(segment, REASON_INNER_DOUBLE_WILDCARD)
+			}
+			builder.WriteString("/**")
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name := segment[1 : len(segment)-1]
+			if !isServeMuxName(name) {
+				unconvertible(segment, REASON_INVALID_SERVEMUX_SYNTAX)
+			}
+			builder.WriteString("/" + segment)
+		case strings.ContainsAny(segment, "{}"):
+			unconvertible(segment, REASON_INVALID_SERVEMUX_SYNTAX)
+		case strings.ContainsAny(segment, "*?"):
+			unconvertible(segment, REASON_ANT_WILDCARD_CHARACTER)
+		default:
+			builder.WriteString("/" + segment)
+		}
+	}
+	if len(conversionError.Unconvertible) > 0 {
+		return "", "", conversionError
+	}
+	return method, builder.String(), nil
+}
+
+// wholeSegmentVariable returns the name of a segment made of a single
+// "{name}" variable without a regex.
+func wholeSegmentVariable(segment string) (string, bool) {
+	if len(segment) < 3 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return "", false
+	}
+	name := segment[1 : len(segment)-1]
+	if strings.ContainsAny(name, "{}:") {
+		return "", false
+	}
+	return name, true
+}
+
+func isServeMuxName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !unicode.IsLetter(c) && c != '_' && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package antpathmatcher
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// @Author :George
+// @File: servemux_test
+// @Version: 1.0.0
+// @Date 2026/10/18 20:50
+
+func Test_toServeMuxPattern(t *testing.T) {
+	e := assert.New(t)
+	cases := []struct {
+		method   string
+		pattern  string
+		expected string
+	}{
+		{"GET", "/files/**", "GET /files/{path...}"},
+		{"", "/hotels/{hotel}", "/hotels/{hotel}"},
+		{"POST", "/hotels/{hotel}/bookings/*", "POST /hotels/{hotel}/bookings/{seg3}"},
+		{"", "/*/x/*", "/{seg0}/x/{seg2}"},
+		{"", "/hotels/", "/hotels/{$}"},
+		{"", "/", "/{$}"},
+		{"", "/**", "/{path...}"},
+		{"", "/a//b", "/a/b"},
+		// generated names do not clash with the pattern's own
+		{"", "/{seg0}/*/{path}/**", "/{seg0}/{seg1}/{path}/{path_...}"},
+		{"", "/{seg1}/*", "/{seg1}/{seg1_}"},
+	}
+	for _, c := range cases {
+		actual, err := ToServeMuxPattern(c.method, c.pattern)
+		e.Nil(err, c.pattern)
+		e.Equal(actual, c.expected, c.pattern)
+	}
+}
+
+func Test_toServeMuxPatternErrors(t *testing.T) {
+	e := assert.New(t)
+	_, err := ToServeMuxPattern("GET", "/hotels/{id:[0-9]+}/*.html/**/x/?/h{x}/{hotel-id}")
+	e.True(errors.Is(err, ErrUnconvertiblePattern))
+	conversionError := &ConversionError{}
+	e.True(errors.As(err, &conversionError))
+	e.Equal(conversionError.Unconvertible, []Unconvertible{
+		{"{id:[0-9]+}", REASON_REGEX_VARIABLE},
+		{"*.html", REASON_SEGMENT_WILDCARD},
+		{"**", REASON_INNER_DOUBLE_WILDCARD},
+		{"?", REASON_SINGLE_CHAR_WILDCARD},
+		{"h{x}", REASON_SEGMENT_WILDCARD},
+		{"{hotel-id}", REASON_INVALID_NAME},
+	})
+	e.Equal(err.Error(), `antpathmatcher: cannot convert "/hotels/{id:[0-9]+}/*.html/**/x/?/h{x}/{hotel-id}": `+
+		`"{id:[0-9]+}" (regular expressions are not supported), "*.html" (wildcards within a segment are not supported), `+
+		`"**" (multi-segment wildcards must be last), "?" (single character wildcards are not supported), `+
+		`"h{x}" (wildcards within a segment are not supported), "{hotel-id}" (wildcard names must be Go identifiers)`)
+
+	_, err = ToServeMuxPattern("", "/{id:[0-9]{3}}")
+	e.True(errors.As(err, &conversionError))
+	e.Equal(conversionError.Unconvertible, []Unconvertible{{"{id:[0-9]{3}}", REASON_REGEX_VARIABLE}})
+
+	_, err = ToServeMuxPattern("", "hotels/*")
+	e.True(errors.As(err, &conversionError))
+	e.Equal(conversionError.Unconvertible, []Unconvertible{{"hotels/*", REASON_RELATIVE_PATTERN}})
+
+	_, err = ToServeMuxPattern("", "/files/**/")
+	e.True(errors.Is(err, ErrUnconvertiblePattern))
+
+	// invalid Ant patterns fail validation first
+	_, err = ToServeMuxPattern("", "/hotels/{hotel")
+	e.True(errors.Is(err, ErrInvalidPattern))
+}
+
+func Test_fromServeMuxPattern(t *testing.T) {
+	e := assert.New(t)
+	cases := []struct {
+		pattern  string
+		method   string
+		expected string
+	}{
+		{"GET /files/{path...}", "GET", "/files/**"},
+		{"/hotels/{hotel}", "", "/hotels/{hotel}"},
+		{"POST \t/hotels/{hotel}/bookings", "POST", "/hotels/{hotel}/bookings"},
+		{"/hotels/{$}", "", "/hotels/"},
+		{"/{$}", "", "/"},
+		{"/hotels/", "", "/hotels/**"},
+		{"/", "", "/**"},
+	}
+	for _, c := range cases {
+		method, actual, err := FromServeMuxPattern(c.pattern)
+		e.Nil(err, c.pattern)
+		e.Equal(method, c.method, c.pattern)
+		e.Equal(actual, c.expected, c.pattern)
+	}
+}
+
+func Test_fromServeMuxPatternErrors(t *testing.T) {
+	e := assert.New(t)
+	conversionError := &ConversionError{}
+	_, _, err := FromServeMuxPattern("GET example.com/a//b*/{x...}/c{d}/{$}/")
+	e.True(errors.Is(err, ErrUnconvertiblePattern))
+	e.True(errors.As(err, &conversionError))
+	e.Equal(conversionError.Unconvertible, []Unconvertible{
+		{"example.com", REASON_HOST},
+		{"//", REASON_EMPTY_SEGMENT},
+		{"b*", REASON_ANT_WILDCARD_CHARACTER},
+		{"{x...}", REASON_INNER_DOUBLE_WILDCARD},
+		{"c{d}", REASON_INVALID_SERVEMUX_SYNTAX},
+		{"{$}", REASON_INVALID_SERVEMUX_SYNTAX},
+	})
+
+	_, _, err = FromServeMuxPattern("GET")
+	e.True(errors.Is(err, ErrUnconvertiblePattern))
+}
+
+// Test_serveMuxPatternsMatchAlike checks that converted patterns match the
+// same paths on both sides, apart from the documented "/files" vs "/files/**".
+func Test_serveMuxPatternsMatchAlike(t *testing.T) {
+	e := assert.New(t)
+	patterns := []string{"/files/**", "/hotels/{hotel}", "/hotels/{hotel}/bookings/*", "/hotels/", "/static/{name}/x"}
+	paths := []string{"/files/a", "/files/a/b/c", "/hotels/1", "/hotels/1/bookings/2", "/hotels/1/bookings",
+		"/hotels/", "/static/a/x", "/static/a/y", "/other"}
+	pathMatcher := NewAntPathMatcher()
+	for _, pattern := range patterns {
+		muxPattern, err := ToServeMuxPattern("", pattern)
+		e.Nil(err)
+		mux := http.NewServeMux()
+		mux.HandleFunc(muxPattern, func(w http.ResponseWriter, r *http.Request) {})
+		for _, path := range paths {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			e.Equal(w.Code == http.StatusOK, pathMatcher.Match(pattern, path), "%s %s %s", pattern, muxPattern, path)
+		}
+
+		_, roundTrip, err := FromServeMuxPattern(muxPattern)
+		e.Nil(err)
+		for _, path := range paths {
+			e.Equal(pathMatcher.Match(roundTrip, path), pathMatcher.Match(pattern, path), "%s %s %s", pattern, roundTrip, path)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package syntax
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"strings"
+)
+
+// @Author :George
+// @File: ant
+// @Version: 1.0.0
+// @Date 2026/10/18 21:20
+
+func parseAnt(pattern string, c *conversion) *parsedRoute {
+	r := &parsedRoute{}
+	if !strings.HasPrefix(pattern, "/") {
+		c.unconvertible(pattern, REASON_RELATIVE_ROUTE)
+		return r
+	}
+	// like AntPathMatcher, ignore empty segments
+	segments := strings.FieldsFunc(pattern, func(c rune) bool { return c == '/' })
+	r.trailingSlash = len(segments) > 0 && strings.HasSuffix(pattern, "/")
+	for k, segment := range segments {
+		last := k == len(segments)-1 && !r.trailingSlash
+		switch segment {
+		case "**":
+			if !last {
+				c.unconvertible(segment, antpathmatcher.REASON_INNER_DOUBLE_WILDCARD)
+			}
+			r.segments = append(r.segments, []part{{kind: catchAllPart}})
+		case "*":
+			r.segments = append(r.segments, []part{{kind: wildcardPart}})
+		default:
+			r.segments = append(r.segments, parseAntSegment(r, segment, c))
+		}
+	}
+	return r
+}
+
+func parseAntSegment(r *parsedRoute, segment string, c *conversion) []part {
+	var parts []part
+	for i := 0; i < len(segment); {
+		switch segment[i] {
+		case '{':
+			end := closing(segment, i, '{', '}')
+			if end < 0 {
+				c.unconvertible(segment, REASON_INVALID_SYNTAX)
+				return parts
+			}
+			name, regex, _ := strings.Cut(segment[i+1:end], ":")
+			r.names.Add(name)
+			parts = append(parts, part{kind: variablePart, text: name, regex: regex})
+			i = end + 1
+		case '*':
+			c.unconvertible(segment, antpathmatcher.REASON_SEGMENT_WILDCARD)
+			return parts
+		case '?':
+			c.unconvertible(segment, antpathmatcher.REASON_SINGLE_CHAR_WILDCARD)
+			return parts
+		default:
+			end := strings.IndexAny(segment[i:], "{*?")
+			if end < 0 {
+				end = len(segment) - i
+			}
+			parts = append(parts, part{kind: literalPart, text: segment[i : i+end]})
+			i += end
+		}
+	}
+	return parts
+}
+
+func formatAntSegment(r *parsedRoute, k int, segment []part, c *conversion) string {
+	builder := strings.Builder{}
+	for _, p := range segment {
+		switch p.kind {
+		case literalPart:
+			if strings.ContainsAny(p.text, "*?{}") {
+				c.unconvertible(p.text, antpathmatcher.REASON_ANT_WILDCARD_CHARACTER)
+			}
+			builder.WriteString(p.text)
+		case variablePart:
+			if p.text == "" || strings.ContainsAny(p.text, ":{}") {
+				c.unconvertible(p.text, REASON_PARAMETER_NAME)
+			}
+			builder.WriteString(antVariable(p))
+		case wildcardPart:
+			builder.WriteString("*")
+		case catchAllPart:
+			builder.WriteString("**")
+		}
+	}
+	return builder.String()
+}
+
+// antSegment writes segment the way an Ant pattern would, to name it in errors.
+func antSegment(segment []part) string {
+	builder := strings.Builder{}
+	for _, p := range segment {
+		switch p.kind {
+		case literalPart:
+			builder.WriteString(p.text)
+		case variablePart:
+			builder.WriteString(antVariable(p))
+		case wildcardPart:
+			builder.WriteString("*")
+		case catchAllPart:
+			builder.WriteString("**")
+		}
+	}
+	return builder.String()
+}
+
+func antVariable(p part) string {
+	if p.regex == "" {
+		return "{" + p.text + "}"
+	}
+	return "{" + p.text + ":" + p.regex + "}"
+}
@@ -0,0 +1,127 @@
+package syntax
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"strings"
+)
+
+// @Author :George
+// @File: braces
+// @Version: 1.0.0
+// @Date 2026/10/18 21:30
+
+// parseBraces parses the "{name}" and "{name:regex}" syntax shared by chi
+// and gorilla/mux. chi applies a regex to one segment and has a trailing "*"
+// catch-all; gorilla/mux lets a regex match "/", which only converts when the
+// variable makes up the last segment.
+func parseBraces(route string, gorilla bool, c *conversion) *parsedRoute {
+	segments, trailingSlash := splitRoute(route, c)
+	r := &parsedRoute{trailingSlash: trailingSlash}
+	for k, segment := range segments {
+		last := k == len(segments)-1 && !trailingSlash
+		if segment == "" {
+			c.unconvertible("//", antpathmatcher.REASON_EMPTY_SEGMENT)
+			continue
+		}
+		var parts []part
+	scan:
+		for i := 0; i < len(segment); {
+			switch {
+			case segment[i] == '{':
+				end := closing(segment, i, '{', '}')
+				if end < 0 {
+					c.unconvertible(segment, REASON_INVALID_SYNTAX)
+					break scan
+				}
+				name, regex, _ := strings.Cut(segment[i+1:end], ":")
+				if name == "" {
+					c.unconvertible(segment[i:end+1], REASON_INVALID_SYNTAX)
+					break scan
+				}
+				if gorilla && regex != "" {
+					slash, err := matchesSlash(regex)
+					if err != nil {
+						c.unconvertible(segment[i:end+1], REASON_INVALID_SYNTAX)
+						break scan
+					}
+					if slash && (i > 0 || end < len(segment)-1 || !last) {
+						c.unconvertible(segment[i:end+1], REASON_SLASH_REGEX)
+						break scan
+					}
+					if slash {
+						parts = append(parts, part{kind: catchAllPart})
+						break scan
+					}
+				}
+				parts = append(parts, part{kind: variablePart, text: name, regex: regex})
+				i = end + 1
+			case segment[i] == '}':
+				c.unconvertible(segment, REASON_INVALID_SYNTAX)
+				break scan
+			case segment[i] == '*' && !gorilla:
+				if segment != "*" {
+					c.unconvertible(segment, antpathmatcher.REASON_SEGMENT_WILDCARD)
+				} else if !last {
+					c.unconvertible(segment, antpathmatcher.REASON_INNER_DOUBLE_WILDCARD)
+				} else {
+					parts = append(parts, part{kind: catchAllPart})
+				}
+				break scan
+			default:
+				special := "{}"
+				if !gorilla {
+					special = "{}*"
+				}
+				end := strings.IndexAny(segment[i:], special)
+				if end < 0 {
+					end = len(segment) - i
+				}
+				parts = append(parts, part{kind: literalPart, text: segment[i : i+end]})
+				i += end
+			}
+		}
+		r.segments = append(r.segments, parts)
+	}
+	return r
+}
+
+func formatChiSegment(r *parsedRoute, k int, segment []part, c *conversion) string {
+	return formatBracesSegment(r, k, segment, false, c)
+}
+
+func formatGorillaSegment(r *parsedRoute, k int, segment []part, c *conversion) string {
+	return formatBracesSegment(r, k, segment, true, c)
+}
+
+func formatBracesSegment(r *parsedRoute, k int, segment []part, gorilla bool, c *conversion) string {
+	builder := strings.Builder{}
+	for _, p := range segment {
+		switch p.kind {
+		case literalPart:
+			if strings.ContainsAny(p.text, "{}") || !gorilla && strings.Contains(p.text, "*") {
+				c.unconvertible(p.text, REASON_SPECIAL_CHARACTER)
+			}
+			builder.WriteString(p.text)
+		case variablePart:
+			if strings.ContainsAny(p.text, ":{}/") {
+				c.unconvertible(antVariable(p), REASON_PARAMETER_NAME)
+			}
+			if gorilla && p.regex != "" {
+				// an Ant regex never sees a "/", a gorilla/mux one may
+				if slash, err := matchesSlash(p.regex); err != nil || slash {
+					c.unconvertible(antVariable(p), REASON_SLASH_REGEX)
+				}
+			}
+			builder.WriteString(antVariable(p))
+		case wildcardPart:
+			builder.WriteString("{" + r.names.Wildcard(k) + "}")
+		case catchAllPart:
+			if gorilla {
+				builder.WriteString("{" + r.names.CatchAll() + ":.*}")
+			} else {
+				builder.WriteString("*")
+			}
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,141 @@
+package syntax
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"strings"
+)
+
+// @Author :George
+// @File: express
+// @Version: 1.0.0
+// @Date 2026/10/18 21:50
+
+// parseExpress parses the Express 4 route syntax: ":name" made of word
+// characters, optionally followed by a "(regex)", and "*", which matches
+// anything and so only converts as the last segment. Optional ("?") and
+// repeated ("+", "*") parameters and characters have no Ant equivalent.
+func parseExpress(route string, c *conversion) *parsedRoute {
+	segments, trailingSlash := splitRoute(route, c)
+	r := &parsedRoute{trailingSlash: trailingSlash}
+	for k, segment := range segments {
+		last := k == len(segments)-1 && !trailingSlash
+		if segment == "" {
+			c.unconvertible("//", antpathmatcher.REASON_EMPTY_SEGMENT)
+			continue
+		}
+		var parts []part
+	scan:
+		for i := 0; i < len(segment); {
+			switch segment[i] {
+			case ':':
+				j := i + 1
+				for j < len(segment) && isWordChar(segment[j]) {
+					j++
+				}
+				if j == i+1 {
+					c.unconvertible(segment, REASON_INVALID_SYNTAX)
+					break scan
+				}
+				p := part{kind: variablePart, text: segment[i+1 : j]}
+				if j < len(segment) && segment[j] == '(' {
+					end := closing(segment, j, '(', ')')
+					if end < 0 {
+						c.unconvertible(segment, REASON_INVALID_SYNTAX)
+						break scan
+					}
+					p.regex = segment[j+1 : end]
+					j = end + 1
+				}
+				if j < len(segment) && segment[j] == '?' {
+					c.unconvertible(segment[i:j+1], REASON_OPTIONAL)
+					break scan
+				}
+				if j < len(segment) && (segment[j] == '+' || segment[j] == '*') {
+					c.unconvertible(segment[i:j+1], REASON_REPEATED)
+					break scan
+				}
+				if p.regex != "" {
+					slash, err := matchesSlash(p.regex)
+					if err != nil {
+						c.unconvertible(segment[i:j], REASON_INVALID_SYNTAX)
+						break scan
+					}
+					if slash && (i > 0 || j < len(segment) || !last) {
+						c.unconvertible(segment[i:j], REASON_SLASH_REGEX)
+						break scan
+					}
+					if slash {
+						p = part{kind: catchAllPart}
+					}
+				}
+				parts = append(parts, p)
+				i = j
+			case '*':
+				if segment != "*" {
+					c.unconvertible(segment, antpathmatcher.REASON_SEGMENT_WILDCARD)
+				} else if !last {
+					c.unconvertible(segment, antpathmatcher.REASON_INNER_DOUBLE_WILDCARD)
+				} else {
+					parts = append(parts, part{kind: catchAllPart})
+				}
+				break scan
+			case '(':
+				c.unconvertible(segment, REASON_UNNAMED_GROUP)
+				break scan
+			case '?':
+				c.unconvertible(segment, REASON_OPTIONAL)
+				break scan
+			case '+':
+				c.unconvertible(segment, REASON_REPEATED)
+				break scan
+			case ')':
+				c.unconvertible(segment, REASON_INVALID_SYNTAX)
+				break scan
+			default:
+				end := strings.IndexAny(segment[i:], ":*()?+")
+				if end < 0 {
+					end = len(segment) - i
+				}
+				parts = append(parts, part{kind: literalPart, text: segment[i : i+end]})
+				i += end
+			}
+		}
+		r.segments = append(r.segments, parts)
+	}
+	return r
+}
+
+func formatExpressSegment(r *parsedRoute, k int, segment []part, c *conversion) string {
+	builder := strings.Builder{}
+	for i, p := range segment {
+		switch p.kind {
+		case literalPart:
+			if strings.ContainsAny(p.text, ":*()?+") {
+				c.unconvertible(p.text, REASON_SPECIAL_CHARACTER)
+			}
+			if i > 0 && segment[i-1].kind == variablePart && p.text != "" && isWordChar(p.text[0]) {
+				c.unconvertible(antSegment(segment), REASON_PARAMETER_FOLLOWED_BY)
+			}
+			builder.WriteString(p.text)
+		case variablePart:
+			for j := 0; j < len(p.text); j++ {
+				if !isWordChar(p.text[j]) {
+					c.unconvertible(antVariable(p), REASON_PARAMETER_NAME)
+					break
+				}
+			}
+			builder.WriteString(":" + p.text)
+			if p.regex != "" {
+				if slash, err := matchesSlash(p.regex); err != nil || slash {
+					c.unconvertible(antVariable(p), REASON_SLASH_REGEX)
+				}
+				builder.WriteString("(" + p.regex + ")")
+			}
+		case wildcardPart:
+			builder.WriteString(":" + r.names.Wildcard(k))
+		case catchAllPart:
+			builder.WriteString("*")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,74 @@
+package syntax
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"strings"
+)
+
+// @Author :George
+// @File: httprouter
+// @Version: 1.0.0
+// @Date 2026/10/18 21:40
+
+// parseHttpRouter parses ":name" parameters, which run to the end of their
+// segment, and the trailing "*name" catch-all.
+func parseHttpRouter(route string, c *conversion) *parsedRoute {
+	segments, trailingSlash := splitRoute(route, c)
+	r := &parsedRoute{trailingSlash: trailingSlash}
+	for k, segment := range segments {
+		last := k == len(segments)-1 && !trailingSlash
+		i := strings.IndexAny(segment, ":*")
+		switch {
+		case segment == "":
+			c.unconvertible("//", antpathmatcher.REASON_EMPTY_SEGMENT)
+		case i < 0:
+			r.segments = append(r.segments, []part{{kind: literalPart, text: segment}})
+		case strings.ContainsAny(segment[i+1:], ":*") || i == len(segment)-1:
+			c.unconvertible(segment, REASON_INVALID_SYNTAX)
+		case segment[i] == ':':
+			var parts []part
+			if i > 0 {
+				parts = append(parts, part{kind: literalPart, text: segment[:i]})
+			}
+			r.segments = append(r.segments, append(parts, part{kind: variablePart, text: segment[i+1:]}))
+		case i > 0:
+			c.unconvertible(segment, REASON_INVALID_SYNTAX)
+		case !last:
+			c.unconvertible(segment, antpathmatcher.REASON_INNER_DOUBLE_WILDCARD)
+		default:
+			r.segments = append(r.segments, []part{{kind: catchAllPart}})
+		}
+	}
+	return r
+}
+
+func formatHttpRouterSegment(r *parsedRoute, k int, segment []part, c *conversion) string {
+	builder := strings.Builder{}
+	for i, p := range segment {
+		switch p.kind {
+		case literalPart:
+			if strings.ContainsAny(p.text, ":*") {
+				c.unconvertible(p.text, REASON_SPECIAL_CHARACTER)
+			}
+			builder.WriteString(p.text)
+		case variablePart:
+			// httprouter has ":name" and "prefix:name"; report the others once
+			last := i == len(segment)-1
+			if !last && segment[i+1].kind != variablePart || last && i > 0 && segment[i-1].kind != literalPart {
+				c.unconvertible(antSegment(segment), REASON_PARAMETER_POSITION)
+			}
+			if p.regex != "" {
+				c.unconvertible(antVariable(p), antpathmatcher.REASON_REGEX_VARIABLE)
+			}
+			if strings.ContainsAny(p.text, ":*") {
+				c.unconvertible(antVariable(p), REASON_PARAMETER_NAME)
+			}
+			builder.WriteString(":" + p.text)
+		case wildcardPart:
+			builder.WriteString(":" + r.names.Wildcard(k))
+		case catchAllPart:
+			builder.WriteString("*" + r.names.CatchAll())
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,252 @@
+package syntax
+
+import (
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/georgeJobs/go-antpathmatcher/internal/naming"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// @Author :George
+// @File: syntax
+// @Version: 1.0.0
+// @Date 2026/10/18 21:10
+
+const (
+	REASON_RELATIVE_ROUTE        = "routes must be absolute"
+	REASON_INVALID_SYNTAX        = "invalid syntax"
+	REASON_OPTIONAL              = "optional parameters and characters are not supported"
+	REASON_REPEATED              = "repeated parameters and characters are not supported"
+	REASON_UNNAMED_GROUP         = "unnamed groups are not supported"
+	REASON_SLASH_REGEX           = "regular expressions matching \"/\" must be the last segment"
+	REASON_PARAMETER_NAME        = "the parameter name is not supported"
+	REASON_PARAMETER_POSITION    = "parameters must end their segment"
+	REASON_PARAMETER_FOLLOWED_BY = "a parameter cannot be followed by this text"
+	REASON_SPECIAL_CHARACTER     = "the character has a meaning in the target syntax"
+)
+
+// Dialect is the route syntax of a router.
+type Dialect int
+
+const (
+	// CHI is github.com/go-chi/chi: "{id}", "{id:[0-9]+}" and a trailing "*".
+	CHI Dialect = iota
+	// GORILLA is github.com/gorilla/mux: "{id}", "{id:[0-9]+}" and
+	// "{rest:.*}", whose regex may match "/".
+	GORILLA
+	// HTTPROUTER is github.com/julienschmidt/httprouter: ":id" and a trailing
+	// "*filepath".
+	HTTPROUTER
+	// EXPRESS is Express 4: ":id", ":id(\\d+)", a trailing "*", and optional
+	// or repeated parameters, which have no Ant equivalent.
+	EXPRESS
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case CHI:
+		return "chi"
+	case GORILLA:
+		return "gorilla/mux"
+	case HTTPROUTER:
+		return "httprouter"
+	case EXPRESS:
+		return "express"
+	default:
+		return "Dialect(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+// ToAnt converts a route written in dialect d to an Ant pattern. Catch-all
+// parameters become "**" and lose their name. Constructs without an Ant
+// equivalent are reported in an *antpathmatcher.ConversionError.
+func ToAnt(d Dialect, route string) (string, error) {
+	c := newConversion(route)
+	var r *parsedRoute
+	switch d {
+	case CHI, GORILLA:
+		r = parseBraces(route, d == GORILLA, c)
+	case HTTPROUTER:
+		r = parseHttpRouter(route, c)
+	case EXPRESS:
+		r = parseExpress(route, c)
+	default:
+		c.unconvertible(route, "unknown dialect "+d.String())
+		return c.result("")
+	}
+	return c.result(r.format(c, formatAntSegment))
+}
+
+// FromAnt converts an Ant pattern to a route in dialect d. A "*" segment
+// becomes a parameter named "segN", N being the index of the segment, and a
+// trailing "**" a catch-all named "path" where the dialect names them. Note
+// that "/files/**" also matches "/files", which the catch-all routes do not.
+func FromAnt(d Dialect, pattern string) (string, error) {
+	if errs := antpathmatcher.Validate(pattern); len(errs) > 0 {
+		return "", &errs[0]
+	}
+	c := newConversion(pattern)
+	r := parseAnt(pattern, c)
+	switch d {
+	case CHI:
+		return c.result(r.format(c, formatChiSegment))
+	case GORILLA:
+		return c.result(r.format(c, formatGorillaSegment))
+	case HTTPROUTER:
+		return c.result(r.format(c, formatHttpRouterSegment))
+	case EXPRESS:
+		return c.result(r.format(c, formatExpressSegment))
+	default:
+		c.unconvertible(pattern, "unknown dialect "+d.String())
+		return c.result("")
+	}
+}
+
+//region parsedRoute
+
+type partKind int
+
+const (
+	literalPart partKind = iota
+	// variablePart is a named variable within one segment, with an optional regex
+	variablePart
+	// wildcardPart is an anonymous wildcard making up a whole segment
+	wildcardPart
+	// catchAllPart makes up the last segment and matches any number of segments
+	catchAllPart
+)
+
+type part struct {
+	kind  partKind
+	text  string
+	regex string
+}
+
+// parsedRoute is the dialect independent form of a route.
+type parsedRoute struct {
+	segments      [][]part
+	trailingSlash bool
+	names         naming.Names
+}
+
+func (r *parsedRoute) format(c *conversion, formatSegment func(r *parsedRoute, k int, segment []part, c *conversion) string) string {
+	builder := strings.Builder{}
+	for k, segment := range r.segments {
+		builder.WriteString("/")
+		builder.WriteString(formatSegment(r, k, segment, c))
+	}
+	if r.trailingSlash || len(r.segments) == 0 {
+		builder.WriteString("/")
+	}
+	return builder.String()
+}
+
+// splitRoute splits an absolute route on the "/" outside of braces and
+// parentheses. The last segment of "/a/" is empty; "/" has no segments.
+func splitRoute(route string, c *conversion) (segments []string, trailingSlash bool) {
+	if !strings.HasPrefix(route, "/") {
+		c.unconvertible(route, REASON_RELATIVE_ROUTE)
+		return nil, false
+	}
+	if route == "/" {
+		return nil, false
+	}
+	depth, start := 0, 1
+	for i := 1; i < len(route); i++ {
+		switch route[i] {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		case '/':
+			if depth == 0 {
+				segments = append(segments, route[start:i])
+				start = i + 1
+			}
+		}
+	}
+	segments = append(segments, route[start:])
+	if segments[len(segments)-1] == "" {
+		return segments[:len(segments)-1], true
+	}
+	return segments, false
+}
+
+//endregion
+
+//region conversion
+
+type conversion struct {
+	err *antpathmatcher.ConversionError
+}
+
+func newConversion(pattern string) *conversion {
+	return &conversion{err: &antpathmatcher.ConversionError{Pattern: pattern}}
+}
+
+func (c *conversion) unconvertible(construct, reason string) {
+	c.err.Unconvertible = append(c.err.Unconvertible, antpathmatcher.Unconvertible{Construct: construct, Reason: reason})
+}
+
+func (c *conversion) result(converted string) (string, error) {
+	if len(c.err.Unconvertible) > 0 {
+		return "", c.err
+	}
+	return converted, nil
+}
+
+//endregion
+
+// matchesSlash reports whether regex can match a string containing "/".
+func matchesSlash(regex string) (bool, error) {
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return false, err
+	}
+	return regexMatchesSlash(re), nil
+}
+
+func regexMatchesSlash(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	case syntax.OpLiteral:
+		return strings.ContainsRune(string(re.Rune), '/')
+	case syntax.OpCharClass:
+		for k := 0; k+1 < len(re.Rune); k += 2 {
+			if re.Rune[k] <= '/' && '/' <= re.Rune[k+1] {
+				return true
+			}
+		}
+		return false
+	}
+	for _, sub := range re.Sub {
+		if regexMatchesSlash(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// closing returns the index of the bracket closing the one at start, or -1.
+func closing(s string, start int, open, close byte) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
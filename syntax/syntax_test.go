@@ -0,0 +1,202 @@
+package syntax
+
+import (
+	"errors"
+	"github.com/georgeJobs/go-antpathmatcher"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// @Author :George
+// @File: syntax_test
+// @Version: 1.0.0
+// @Date 2026/10/18 22:00
+
+func Test_toAnt(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		dialect Dialect
+		route   string
+		ant     string
+	}{
+		{CHI, "/", "/"},
+		{CHI, "/users", "/users"},
+		{CHI, "/users/", "/users/"},
+		{CHI, "/users/{id}", "/users/{id}"},
+		{CHI, "/users/{id:[0-9]+}/posts", "/users/{id:[0-9]+}/posts"},
+		{CHI, "/files/{name}.{ext}", "/files/{name}.{ext}"},
+		{CHI, "/static/*", "/static/**"},
+		{CHI, "/date/{d:\\d{4}-\\d{2}}", "/date/{d:\\d{4}-\\d{2}}"},
+		{GORILLA, "/users/{id:[0-9]+}", "/users/{id:[0-9]+}"},
+		{GORILLA, "/static/{rest:.*}", "/static/**"},
+		{HTTPROUTER, "/users/:id", "/users/{id}"},
+		{HTTPROUTER, "/users/v:version", "/users/v{version}"},
+		{HTTPROUTER, "/src/*filepath", "/src/**"},
+		{EXPRESS, "/users/:id", "/users/{id}"},
+		{EXPRESS, "/users/:id(\\d+)", "/users/{id:\\d+}"},
+		{EXPRESS, "/flights/:from-:to", "/flights/{from}-{to}"},
+		{EXPRESS, "/files/*", "/files/**"},
+		{EXPRESS, "/files/:rest(.*)", "/files/**"},
+	}
+	for _, test := range tests {
+		ant, err := ToAnt(test.dialect, test.route)
+		e.NoError(err, "%v %s", test.dialect, test.route)
+		e.Equal(ant, test.ant, "%v %s", test.dialect, test.route)
+	}
+}
+
+func Test_toAntErrors(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		dialect   Dialect
+		route     string
+		construct string
+		reason    string
+	}{
+		{CHI, "users", "users", REASON_RELATIVE_ROUTE},
+		{CHI, "/a//b", "//", antpathmatcher.REASON_EMPTY_SEGMENT},
+		{CHI, "/*/b", "*", antpathmatcher.REASON_INNER_DOUBLE_WILDCARD},
+		{CHI, "/a*", "a*", antpathmatcher.REASON_SEGMENT_WILDCARD},
+		{CHI, "/{id", "{id", REASON_INVALID_SYNTAX},
+		{CHI, "/{}", "{}", REASON_INVALID_SYNTAX},
+		{GORILLA, "/{rest:.*}/b", "{rest:.*}", REASON_SLASH_REGEX},
+		{GORILLA, "/a{rest:.*}", "{rest:.*}", REASON_SLASH_REGEX},
+		{GORILLA, "/{id:[}", "{id:[}", REASON_INVALID_SYNTAX},
+		{GORILLA, "/a*b", "a*b", antpathmatcher.REASON_ANT_WILDCARD_CHARACTER},
+		{HTTPROUTER, "/*filepath/b", "*filepath", antpathmatcher.REASON_INNER_DOUBLE_WILDCARD},
+		{HTTPROUTER, "/:a:b", ":a:b", REASON_INVALID_SYNTAX},
+		{HTTPROUTER, "/a*b", "a*b", REASON_INVALID_SYNTAX},
+		{HTTPROUTER, "/a/:", ":", REASON_INVALID_SYNTAX},
+		{EXPRESS, "/users/:id?", ":id?", REASON_OPTIONAL},
+		{EXPRESS, "/ab?cd", "ab?cd", REASON_OPTIONAL},
+		{EXPRESS, "/:ids+", ":ids+", REASON_REPEATED},
+		{EXPRESS, "/:id(\\d+)*", ":id(\\d+)*", REASON_REPEATED},
+		{EXPRESS, "/ab+cd", "ab+cd", REASON_REPEATED},
+		{EXPRESS, "/ab(cd)", "ab(cd)", REASON_UNNAMED_GROUP},
+		{EXPRESS, "/ab*cd", "ab*cd", antpathmatcher.REASON_SEGMENT_WILDCARD},
+		{EXPRESS, "/*/b", "*", antpathmatcher.REASON_INNER_DOUBLE_WILDCARD},
+		{EXPRESS, "/:rest(.*)/b", ":rest(.*)", REASON_SLASH_REGEX},
+		{EXPRESS, "/:", ":", REASON_INVALID_SYNTAX},
+	}
+	for _, test := range tests {
+		_, err := ToAnt(test.dialect, test.route)
+		var conversionError *antpathmatcher.ConversionError
+		if !e.True(errors.As(err, &conversionError), "%v %s", test.dialect, test.route) {
+			continue
+		}
+		e.True(errors.Is(err, antpathmatcher.ErrUnconvertiblePattern))
+		e.Equal(conversionError.Pattern, test.route)
+		e.Equal(conversionError.Unconvertible, []antpathmatcher.Unconvertible{{Construct: test.construct, Reason: test.reason}}, "%v %s", test.dialect, test.route)
+	}
+	_, err := ToAnt(Dialect(9), "/")
+	e.ErrorIs(err, antpathmatcher.ErrUnconvertiblePattern)
+}
+
+func Test_fromAnt(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		pattern    string
+		chi        string
+		gorilla    string
+		httprouter string
+		express    string
+	}{
+		{"/", "/", "/", "/", "/"},
+		{"/users/", "/users/", "/users/", "/users/", "/users/"},
+		{"/users/{id}", "/users/{id}", "/users/{id}", "/users/:id", "/users/:id"},
+		{"/users/v{version}", "/users/v{version}", "/users/v{version}", "/users/v:version", "/users/v:version"},
+		{"/users/*/posts", "/users/{seg1}/posts", "/users/{seg1}/posts", "/users/:seg1/posts", "/users/:seg1/posts"},
+		{"/static/**", "/static/*", "/static/{path:.*}", "/static/*path", "/static/*"},
+		{"/{path}/**", "/{path}/*", "/{path}/{path_:.*}", "/:path/*path_", "/:path/*"},
+		{"/{seg0}/*", "/{seg0}/{seg1}", "/{seg0}/{seg1}", "/:seg0/:seg1", "/:seg0/:seg1"},
+		{"/*/{seg0}", "/{seg0_}/{seg0}", "/{seg0_}/{seg0}", "/:seg0_/:seg0", "/:seg0_/:seg0"},
+		{"//a///b", "/a/b", "/a/b", "/a/b", "/a/b"},
+	}
+	for _, test := range tests {
+		for d, expected := range map[Dialect]string{CHI: test.chi, GORILLA: test.gorilla, HTTPROUTER: test.httprouter, EXPRESS: test.express} {
+			route, err := FromAnt(d, test.pattern)
+			e.NoError(err, "%v %s", d, test.pattern)
+			e.Equal(route, expected, "%v %s", d, test.pattern)
+		}
+	}
+}
+
+func Test_fromAntErrors(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		dialect   Dialect
+		pattern   string
+		construct string
+		reason    string
+	}{
+		{CHI, "users", "users", REASON_RELATIVE_ROUTE},
+		{CHI, "/**/a", "**", antpathmatcher.REASON_INNER_DOUBLE_WILDCARD},
+		{CHI, "/*.html", "*.html", antpathmatcher.REASON_SEGMENT_WILDCARD},
+		{CHI, "/a?c", "a?c", antpathmatcher.REASON_SINGLE_CHAR_WILDCARD},
+		{CHI, "/?", "?", antpathmatcher.REASON_SINGLE_CHAR_WILDCARD},
+		{GORILLA, "/{rest:.*}", "{rest:.*}", REASON_SLASH_REGEX},
+		{HTTPROUTER, "/{id:[0-9]+}", "{id:[0-9]+}", antpathmatcher.REASON_REGEX_VARIABLE},
+		{HTTPROUTER, "/{id}.json", "{id}.json", REASON_PARAMETER_POSITION},
+		{HTTPROUTER, "/{a}{b}", "{a}{b}", REASON_PARAMETER_POSITION},
+		{HTTPROUTER, "/a:b", "a:b", REASON_SPECIAL_CHARACTER},
+		{EXPRESS, "/{id}abc", "{id}abc", REASON_PARAMETER_FOLLOWED_BY},
+		{EXPRESS, "/{user-id}", "{user-id}", REASON_PARAMETER_NAME},
+		{EXPRESS, "/a+b", "a+b", REASON_SPECIAL_CHARACTER},
+		{EXPRESS, "/{rest:.*}", "{rest:.*}", REASON_SLASH_REGEX},
+		{CHI, "/a}", "a}", REASON_SPECIAL_CHARACTER},
+	}
+	for _, test := range tests {
+		_, err := FromAnt(test.dialect, test.pattern)
+		var conversionError *antpathmatcher.ConversionError
+		if !e.True(errors.As(err, &conversionError), "%v %s: %v", test.dialect, test.pattern, err) {
+			continue
+		}
+		e.Equal(conversionError.Unconvertible, []antpathmatcher.Unconvertible{{Construct: test.construct, Reason: test.reason}}, "%v %s", test.dialect, test.pattern)
+	}
+	_, err := FromAnt(CHI, "/{id:(a)}")
+	e.ErrorIs(err, antpathmatcher.ErrCapturingGroup)
+}
+
+// Test_roundTrip converts routes to Ant patterns and back again, and checks
+// that the patterns match what the routes match.
+func Test_roundTrip(t *testing.T) {
+	e := assert.New(t)
+	tests := []struct {
+		dialect Dialect
+		route   string
+		path    string
+		vars    map[string]string
+	}{
+		{CHI, "/users/{id:[0-9]+}/posts/{post}", "/users/42/posts/hello", map[string]string{"id": "42", "post": "hello"}},
+		{GORILLA, "/files/{name}.{ext}", "/files/report.pdf", map[string]string{"name": "report", "ext": "pdf"}},
+		{HTTPROUTER, "/users/:id/", "/users/7/", map[string]string{"id": "7"}},
+		{EXPRESS, "/flights/:from-:to", "/flights/LAX-SFO", map[string]string{"from": "LAX", "to": "SFO"}},
+	}
+	pathMatcher := antpathmatcher.NewAntPathMatcher()
+	for _, test := range tests {
+		ant, err := ToAnt(test.dialect, test.route)
+		e.NoError(err)
+		vars, err := pathMatcher.TryExtractUriTemplateVariables(ant, test.path)
+		e.NoError(err, "%s %s", ant, test.path)
+		e.Equal(vars, test.vars)
+		route, err := FromAnt(test.dialect, ant)
+		e.NoError(err)
+		e.Equal(route, test.route)
+	}
+	for _, route := range []string{"/static/*", "/"} {
+		ant, err := ToAnt(CHI, route)
+		e.NoError(err)
+		back, err := FromAnt(CHI, ant)
+		e.NoError(err)
+		e.Equal(back, route)
+	}
+}
+
+func Test_dialectString(t *testing.T) {
+	e := assert.New(t)
+	e.Equal(CHI.String(), "chi")
+	e.Equal(GORILLA.String(), "gorilla/mux")
+	e.Equal(HTTPROUTER.String(), "httprouter")
+	e.Equal(EXPRESS.String(), "express")
+	e.Equal(Dialect(9).String(), "Dialect(9)")
+}
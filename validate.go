@@ -0,0 +1,166 @@
+package antpathmatcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// @Author :George
+// @File: validate
+// @Version: 1.0.0
+// @Date 2026/10/18 11:10
+
+const (
+	REASON_UNCLOSED_VARIABLE  = "unclosed variable"
+	REASON_EMPTY_VARIABLE     = "empty variable name"
+	REASON_DUPLICATE_VARIABLE = "duplicate variable name"
+	REASON_CAPTURING_GROUP    = "capturing group in variable regex"
+	REASON_CAPTURING_PATTERN  = "capturing pattern variables are not supported"
+	REASON_INVALID_REGEX      = "invalid variable regex"
+)
+
+// ParseError points at a syntax error in a pattern. Offset is the byte offset
+// into Pattern and Segment the index of the path segment that contains it.
+type ParseError struct {
+	Pattern string
+	Offset  int
+	Segment int
+	Reason  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("antpathmatcher: invalid pattern %q at offset %d (segment %d): %s", e.Pattern, e.Offset, e.Segment, e.Reason)
+}
+
+func (e *ParseError) Unwrap() error {
+	switch e.Reason {
+	case REASON_CAPTURING_GROUP:
+		return ErrCapturingGroup
+	case REASON_CAPTURING_PATTERN:
+		return ErrCapturingPattern
+	default:
+		return ErrInvalidPattern
+	}
+}
+
+// fatal reports whether the error makes the pattern fail at match time.
+func (e *ParseError) fatal() bool {
+	return e.Reason == REASON_CAPTURING_GROUP || e.Reason == REASON_CAPTURING_PATTERN ||
+		strings.HasPrefix(e.Reason, REASON_INVALID_REGEX)
+}
+
+// Validate reports every syntax error in pattern, in order of appearance.
+// It is stricter than Compile, which only fails on the errors that would make
+// matching panic.
+func Validate(pattern string, opts ...Option) []ParseError {
+	o := newOptions(opts...)
+	v := &validator{pattern: pattern, names: make(map[string]bool)}
+	segment, start := 0, 0
+	for pos := 0; pos <= len(pattern); pos++ {
+		sep := 0
+		if pos < len(pattern) {
+			sep = separatorLength(pattern[pos:], o.pathSeparator)
+			if sep == 0 {
+				continue
+			}
+		}
+		token := pattern[start:pos]
+		if o.trimTokens {
+			token = strings.TrimSpace(token)
+		}
+		if token != "" {
+			v.validateSegment(start, pos, segment)
+			segment++
+		}
+		start = pos + sep
+		if sep > 1 {
+			pos += sep - 1
+		}
+	}
+	return v.errs
+}
+
+// separatorLength returns the length in bytes of the separator rune that s
+// starts with, or 0 if it does not start with one. Like tokenizePath, any rune
+// of separators is a separator.
+func separatorLength(s string, separators string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	if strings.ContainsRune(separators, r) {
+		return size
+	}
+	return 0
+}
+
+type validator struct {
+	pattern string
+	names   map[string]bool
+	errs    []ParseError
+}
+
+func (v *validator) fail(offset, segment int, reason string) {
+	v.errs = append(v.errs, ParseError{Pattern: v.pattern, Offset: offset, Segment: segment, Reason: reason})
+}
+
+func (v *validator) validateSegment(start, end, segment int) {
+	for pos := start; pos < end; pos++ {
+		if v.pattern[pos] != '{' {
+			continue
+		}
+		closing := v.findVariableEnd(pos+1, end)
+		if closing == -1 {
+			v.fail(pos, segment, REASON_UNCLOSED_VARIABLE)
+			return
+		}
+		v.validateVariable(pos, closing, segment)
+		pos = closing
+	}
+}
+
+// findVariableEnd returns the offset of the '}' closing a variable whose body
+// starts at pos, skipping escaped braces and regex quantifiers such as \d{4}.
+func (v *validator) findVariableEnd(pos, end int) int {
+	depth := 0
+	for ; pos < end; pos++ {
+		switch v.pattern[pos] {
+		case '\\':
+			pos++
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return pos
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+func (v *validator) validateVariable(open, closing, segment int) {
+	body := v.pattern[open+1 : closing]
+	name, variablePattern, hasPattern := body, "", false
+	if colonIdx := strings.IndexByte(body, ':'); colonIdx != -1 {
+		name, variablePattern, hasPattern = body[:colonIdx], body[colonIdx+1:], true
+	}
+	switch {
+	case name == "":
+		v.fail(open, segment, REASON_EMPTY_VARIABLE)
+	case strings.HasPrefix(name, "*"):
+		v.fail(open, segment, REASON_CAPTURING_PATTERN)
+	case v.names[name]:
+		v.fail(open, segment, REASON_DUPLICATE_VARIABLE)
+	}
+	v.names[name] = true
+	if !hasPattern {
+		return
+	}
+	offset := open + 1 + len(name) + 1
+	compiled, err := regexp.Compile(variablePattern)
+	if err != nil {
+		v.fail(offset, segment, REASON_INVALID_REGEX+": "+err.Error())
+	} else if compiled.NumSubexp() > 0 {
+		v.fail(offset, segment, REASON_CAPTURING_GROUP)
+	}
+}
@@ -0,0 +1,95 @@
+package antpathmatcher
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// @Author :George
+// @File: validate_test
+// @Version: 1.0.0
+// @Date 2026/10/18 11:40
+
+func Test_validate(t *testing.T) {
+	e := assert.New(t)
+	e.Nil(Validate("/hotels/{hotel}/bookings/{booking}"))
+	e.Nil(Validate("{symbolicName:[\\w\\.]+}-sources-{version:[\\d\\.]+}-{year:\\d{4}}{month:\\d{2}}{day:\\d{2}}.jar"))
+	e.Nil(Validate("{symbolicName:[\\p{L}\\.]+}-sources-{version:[\\p{N}\\.\\{\\}]+}.jar"))
+	e.Nil(Validate("/**/*.html"))
+
+	errs := Validate("/users/{id")
+	e.Len(errs, 1)
+	e.Equal(errs[0], ParseError{Pattern: "/users/{id", Offset: 7, Segment: 1, Reason: REASON_UNCLOSED_VARIABLE})
+
+	errs = Validate("/users/{}/{:[0-9]+}")
+	e.Len(errs, 2)
+	e.Equal(errs[0].Offset, 7)
+	e.Equal(errs[0].Reason, REASON_EMPTY_VARIABLE)
+	e.Equal(errs[1].Offset, 10)
+	e.Equal(errs[1].Segment, 2)
+
+	errs = Validate("/{id}/x/{id}")
+	e.Len(errs, 1)
+	e.Equal(errs[0].Offset, 8)
+	e.Equal(errs[0].Segment, 2)
+	e.Equal(errs[0].Reason, REASON_DUPLICATE_VARIABLE)
+
+	errs = Validate("/web/{id:foo(bar)?}")
+	e.Len(errs, 1)
+	e.Equal(errs[0].Offset, 9)
+	e.Equal(errs[0].Reason, REASON_CAPTURING_GROUP)
+	e.True(errors.Is(&errs[0], ErrCapturingGroup))
+
+	errs = Validate("/users/{id:[0-9}")
+	e.Len(errs, 1)
+	e.Equal(errs[0].Offset, 11)
+	e.Contains(errs[0].Reason, REASON_INVALID_REGEX)
+	e.True(errors.Is(&errs[0], ErrInvalidPattern))
+
+	errs = Validate("/files/{*path}")
+	e.Len(errs, 1)
+	e.True(errors.Is(&errs[0], ErrCapturingPattern))
+}
+
+func Test_validateWithOptions(t *testing.T) {
+	e := assert.New(t)
+	errs := Validate(".a.{b", WithPathSeparator("."))
+	e.Len(errs, 1)
+	e.Equal(errs[0].Offset, 3)
+	e.Equal(errs[0].Segment, 1)
+
+	errs = Validate("/ /{b", WithTrimTokens(true))
+	e.Len(errs, 1)
+	e.Equal(errs[0].Segment, 0)
+
+	errs = Validate("/a→{x}→{x}", WithPathSeparator("→"))
+	e.Len(errs, 1)
+	e.Equal(errs[0].Reason, REASON_DUPLICATE_VARIABLE)
+	e.Equal(errs[0].Offset, 11)
+	e.Equal(errs[0].Segment, 2)
+}
+
+func Test_compileReturnsParseError(t *testing.T) {
+	e := assert.New(t)
+	_, err := Compile("/hotels/{hotel:[0-9}")
+	var parseError *ParseError
+	e.True(errors.As(err, &parseError))
+	e.Equal(parseError.Offset, 15)
+	e.True(strings.HasPrefix(parseError.Reason, REASON_INVALID_REGEX))
+	e.True(errors.Is(err, ErrInvalidPattern))
+}
+
+func Test_compileAcceptsWhatMatchAccepts(t *testing.T) {
+	e := assert.New(t)
+	pathMatcher := NewAntPathMatcher()
+	for _, pattern := range []string{"/hotels/{hotel", "/{id}/x/{id}", "/users/{}"} {
+		e.NotNil(Validate(pattern), pattern)
+		p, err := Compile(pattern)
+		if e.NoError(err, pattern) {
+			e.Equal(p.Match(pattern), pathMatcher.Match(pattern, pattern), pattern)
+		}
+	}
+	e.True(MustCompile("/hotels/{hotel").Match("/hotels/{hotel"))
+}